@@ -0,0 +1,17 @@
+data "huggingface-spaces_hardware_flavors" "all" {}
+
+locals {
+  # Cheapest GPU flavor with at least 24 GB of accelerator memory.
+  large_gpus = [
+    for flavor in data.huggingface-spaces_hardware_flavors.all.flavors : flavor
+    if flavor.accelerator_memory_gb != null && flavor.accelerator_memory_gb >= 24
+  ]
+  cheapest_large_gpu = [
+    for flavor in local.large_gpus : flavor.name
+    if flavor.hourly_price == min(local.large_gpus[*].hourly_price...)
+  ][0]
+}
+
+output "cheapest_large_gpu" {
+  value = local.cheapest_large_gpu
+}
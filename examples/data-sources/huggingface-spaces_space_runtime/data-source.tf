@@ -0,0 +1,12 @@
+data "huggingface-spaces_space_runtime" "demo" {
+  id        = huggingface-spaces_space.demo.id
+  log_lines = 100
+}
+
+output "demo_stage" {
+  value = data.huggingface-spaces_space_runtime.demo.stage
+}
+
+output "demo_build_logs" {
+  value = join("\n", coalesce(data.huggingface-spaces_space_runtime.demo.build_logs, []))
+}
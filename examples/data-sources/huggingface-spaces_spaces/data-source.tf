@@ -0,0 +1,9 @@
+data "huggingface-spaces_spaces" "org" {
+  author = "my-org"
+  filter = ["gradio"]
+  sort   = "likes"
+}
+
+output "org_space_ids" {
+  value = data.huggingface-spaces_spaces.org.spaces[*].id
+}
@@ -0,0 +1,14 @@
+data "huggingface-spaces_whoami" "current" {
+  lifecycle {
+    postcondition {
+      condition = anytrue([
+        for org in self.organizations : org.name == "my-org" && contains(["admin", "write"], org.role)
+      ])
+      error_message = "The token must belong to a member of my-org with write access."
+    }
+  }
+}
+
+output "username" {
+  value = data.huggingface-spaces_whoami.current.username
+}
@@ -0,0 +1,5 @@
+resource "huggingface-spaces_collection" "demos" {
+  title       = "Demo Spaces"
+  namespace   = "my-org"
+  description = "Interactive demos of our models."
+}
@@ -0,0 +1,7 @@
+resource "huggingface-spaces_collection_item" "demo" {
+  collection_slug = huggingface-spaces_collection.demos.slug
+  item_type       = "space"
+  item_id         = huggingface-spaces_space.demo.id
+  note            = "Try the sentiment classifier in your browser."
+  position        = 0
+}
@@ -0,0 +1,16 @@
+resource "huggingface-spaces_dataset" "reviews" {
+  name    = "product-reviews"
+  private = true
+  gated   = "auto"
+  license = "cc-by-4.0"
+  tags    = ["reviews"]
+}
+
+resource "huggingface-spaces_space" "demo" {
+  name = "reviews-demo"
+  sdk  = "gradio"
+
+  variables = {
+    DATASET_ID = huggingface-spaces_dataset.reviews.id
+  }
+}
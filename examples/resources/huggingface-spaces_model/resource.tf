@@ -0,0 +1,19 @@
+resource "huggingface-spaces_model" "classifier" {
+  name    = "sentiment-classifier"
+  private = true
+  gated   = "manual"
+  license = "apache-2.0"
+  tags    = ["text-classification", "sentiment"]
+
+  gated_prompt = "Access is limited to members of our evaluation program."
+  gated_fields = {
+    "Company"                     = "text"
+    "I agree to the usage policy" = "checkbox"
+  }
+
+  card = <<-EOT
+    # Sentiment classifier
+
+    Fine-tuned model served by our demo Space.
+  EOT
+}
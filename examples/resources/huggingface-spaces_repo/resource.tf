@@ -0,0 +1,6 @@
+resource "huggingface-spaces_repo" "embeddings" {
+  repo_type = "dataset"
+  name      = "review-embeddings"
+  namespace = "my-org"
+  private   = true
+}
@@ -0,0 +1,13 @@
+resource "huggingface-spaces_repo_access_request" "evaluator" {
+  repo_type = "model"
+  repo_id   = huggingface-spaces_model.classifier.id
+  user      = "jane-evaluator"
+}
+
+resource "huggingface-spaces_repo_access_request" "spam" {
+  repo_type        = "model"
+  repo_id          = huggingface-spaces_model.classifier.id
+  user             = "spam-account"
+  status           = "rejected"
+  rejection_reason = "Incomplete access request form."
+}
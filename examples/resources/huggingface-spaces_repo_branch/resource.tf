@@ -0,0 +1,6 @@
+resource "huggingface-spaces_repo_branch" "staging" {
+  repo_type = "space"
+  repo_id   = huggingface-spaces_space.demo.id
+  branch    = "staging"
+  revision  = "v1.2.0"
+}
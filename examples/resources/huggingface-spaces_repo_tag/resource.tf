@@ -0,0 +1,7 @@
+resource "huggingface-spaces_repo_tag" "release" {
+  repo_type = "model"
+  repo_id   = huggingface-spaces_model.classifier.id
+  tag       = "v1.0"
+  revision  = "3f2c7a1d9e0b4c6a8f5d2e1b7c9a0d4e6f8b2c1a"
+  message   = "First release served by the demo Space"
+}
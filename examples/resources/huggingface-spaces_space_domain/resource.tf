@@ -0,0 +1,8 @@
+resource "huggingface-spaces_space_domain" "demo" {
+  space_id = huggingface-spaces_space.demo.id
+  domain   = "demo.example.com"
+}
+
+output "demo_dns_records" {
+  value = huggingface-spaces_space_domain.demo.dns_records
+}
@@ -0,0 +1,16 @@
+resource "huggingface-spaces_webhook" "ci" {
+  url     = "https://ci.example.com/hooks/huggingface"
+  domains = ["repo"]
+  secret  = var.webhook_secret
+
+  watched = [
+    {
+      type = "space"
+      name = huggingface-spaces_space.demo.id
+    },
+    {
+      type = "org"
+      name = "my-org"
+    },
+  ]
+}
@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/hashicorp/terraform-plugin-docs v0.19.2
 	github.com/hashicorp/terraform-plugin-framework v1.8.0
+	github.com/hashicorp/terraform-plugin-framework-validators v0.12.0
 )
 
 require (
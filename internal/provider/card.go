@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// repoURLPrefix returns the path segment the Hub uses for a repository type
+// in both website and API URLs ("spaces", "datasets", or "models").
+func repoURLPrefix(repoType string) string {
+	return repoType + "s"
+}
+
+// repoWebURL returns the website URL of a repository. Models live at the
+// root of the Hub rather than under a "models/" prefix.
+func repoWebURL(repoType, repoID string) string {
+	if repoType == "model" {
+		return fmt.Sprintf("https://huggingface.co/%s", repoID)
+	}
+
+	return fmt.Sprintf("https://huggingface.co/%s/%s", repoURLPrefix(repoType), repoID)
+}
+
+// fetchRepoReadme returns the README.md of a repository's main branch, or an
+// empty string when the repository has no README yet.
+func fetchRepoReadme(client *http.Client, repoType, repoID string) (string, error) {
+	url := fmt.Sprintf("%s/raw/main/README.md", repoWebURL(repoType, repoID))
+
+	httpResp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// commitRepoReadme replaces README.md on the main branch of a repository with
+// a single commit. For Spaces, any commit triggers a rebuild.
+func commitRepoReadme(client *http.Client, repoType, repoID, content, summary string) error {
+	url := fmt.Sprintf("https://huggingface.co/api/%s/%s/commit/main", repoURLPrefix(repoType), repoID)
+
+	header, err := json.Marshal(map[string]interface{}{
+		"key": "header",
+		"value": map[string]string{
+			"summary":     summary,
+			"description": "",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	file, err := json.Marshal(map[string]interface{}{
+		"key": "file",
+		"value": map[string]string{
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+			"path":     "README.md",
+			"encoding": "base64",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	reqBody := string(header) + "\n" + string(file) + "\n"
+
+	httpResp, err := client.Post(url, "application/x-ndjson", strings.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// updateRepoCardMetadata rewrites the given top-level keys of a repository's
+// card metadata and commits the result. See setCardMetadata for the format of
+// values.
+func updateRepoCardMetadata(client *http.Client, repoType, repoID string, values map[string]string) error {
+	return updateRepoCard(client, repoType, repoID, values, nil)
+}
+
+// updateRepoCard rewrites the given card metadata keys and, when body is not
+// nil, the Markdown below the metadata, and commits the result in a single
+// commit.
+func updateRepoCard(client *http.Client, repoType, repoID string, values map[string]string, body *string) error {
+	readme, err := fetchRepoReadme(client, repoType, repoID)
+	if err != nil {
+		return fmt.Errorf("unable to read README.md: %w", err)
+	}
+
+	updated := setCardMetadata(readme, values)
+	if body != nil {
+		updated = setCardBody(updated, *body)
+	}
+	if updated == readme {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Updating card of %s %s: %+v", repoType, repoID, values)
+
+	summary := "Update card metadata"
+	if body != nil {
+		summary = "Update README.md"
+	}
+
+	if err := commitRepoReadme(client, repoType, repoID, updated, summary); err != nil {
+		return fmt.Errorf("unable to commit README.md: %w", err)
+	}
+
+	return nil
+}
+
+// cardString renders a string as a card metadata value. Values are always
+// quoted so that versions such as 3.10 are not read back as numbers.
+func cardString(value string) string {
+	return " " + strconv.Quote(value)
+}
+
+// cardInt renders an integer as a card metadata value.
+func cardInt(value int64) string {
+	return fmt.Sprintf(" %d", value)
+}
+
+// cardList renders a list of strings as a card metadata value.
+func cardList(values []string) string {
+	if len(values) == 0 {
+		return " []"
+	}
+
+	var b strings.Builder
+	for _, value := range values {
+		b.WriteString("\n-" + cardString(value))
+	}
+
+	return b.String()
+}
+
+// cardMap renders a map of strings as a card metadata value, sorted by key.
+func cardMap(values map[string]string) string {
+	if len(values) == 0 {
+		return " {}"
+	}
+
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString("\n  " + strconv.Quote(key) + ":" + cardString(values[key]))
+	}
+
+	return b.String()
+}
+
+// cardDataString returns a string from the card metadata of a repository,
+// or null if it is not set. The Hub parses unquoted versions such as 3.10 as
+// numbers, which lose their trailing zeros, so current is kept when it is the
+// same number.
+func cardDataString(cardData map[string]interface{}, key string, current types.String) types.String {
+	switch value := cardData[key].(type) {
+	case string:
+		return types.StringValue(value)
+	case float64:
+		if number, err := strconv.ParseFloat(current.ValueString(), 64); err == nil && number == value {
+			return current
+		}
+		return types.StringValue(strconv.FormatFloat(value, 'f', -1, 64))
+	}
+
+	return types.StringNull()
+}
+
+// cardDataInt64 returns an integer from the card metadata of a repository, or
+// null if it is not set.
+func cardDataInt64(cardData map[string]interface{}, key string) types.Int64 {
+	if value, ok := cardData[key].(float64); ok {
+		return types.Int64Value(int64(value))
+	}
+
+	return types.Int64Null()
+}
+
+// cardDataStringMap returns a map of strings from the card metadata of a
+// repository, or null if it is not set. Entries that are not strings are
+// skipped.
+func cardDataStringMap(cardData map[string]interface{}, key string) types.Map {
+	entries, ok := cardData[key].(map[string]interface{})
+	if !ok {
+		return types.MapNull(types.StringType)
+	}
+
+	values := make(map[string]attr.Value, len(entries))
+	for name, entry := range entries {
+		if value, ok := entry.(string); ok {
+			values[name] = types.StringValue(value)
+		}
+	}
+
+	result, _ := types.MapValue(types.StringType, values)
+
+	return result
+}
+
+// setCardMetadata sets top-level keys in the YAML front matter of a README,
+// creating the front matter if needed. Each value is the rendered YAML that
+// follows "key:", as produced by cardString and friends; an empty value
+// removes the key. Everything else in the README is left untouched.
+func setCardMetadata(readme string, values map[string]string) string {
+	lines, body := splitCardMetadata(readme)
+
+	var out []string
+	seen := make(map[string]bool)
+	skipping := false
+
+	for _, line := range lines {
+		// Continuation lines of a block value belong to the key above them.
+		if skipping && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "-")) {
+			continue
+		}
+		skipping = false
+
+		key := cardMetadataKey(line)
+		value, ok := values[key]
+		if key == "" || !ok {
+			out = append(out, line)
+			continue
+		}
+
+		seen[key] = true
+		skipping = true
+		if value != "" {
+			out = append(out, key+":"+value)
+		}
+	}
+
+	var keys []string
+	for key, value := range values {
+		if !seen[key] && value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		out = append(out, key+":"+values[key])
+	}
+
+	if len(out) == 0 {
+		return body
+	}
+
+	return "---\n" + strings.Join(out, "\n") + "\n---\n" + body
+}
+
+// setCardBody replaces the Markdown of a README below its front matter.
+func setCardBody(readme, body string) string {
+	lines, _ := splitCardMetadata(readme)
+	if len(lines) == 0 {
+		return body
+	}
+
+	return "---\n" + strings.Join(lines, "\n") + "\n---\n" + body
+}
+
+// cardBody returns the Markdown of a README below its front matter.
+func cardBody(readme string) string {
+	_, body := splitCardMetadata(readme)
+
+	return body
+}
+
+// splitCardMetadata splits a README into its front matter lines and the
+// remaining body.
+func splitCardMetadata(readme string) ([]string, string) {
+	normalized := strings.ReplaceAll(readme, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return nil, readme
+	}
+
+	rest := strings.TrimPrefix(normalized, "---\n")
+	if strings.HasPrefix(rest, "---") {
+		return nil, strings.TrimPrefix(strings.TrimPrefix(rest, "---"), "\n")
+	}
+
+	end := strings.Index(rest, "\n---")
+	if end == -1 {
+		return nil, readme
+	}
+
+	header := rest[:end]
+	body := strings.TrimPrefix(rest[end+len("\n---"):], "\n")
+
+	return strings.Split(header, "\n"), body
+}
+
+// cardMetadataKey returns the key of a top-level front matter line, or an
+// empty string for comments, continuation lines and anything else.
+func cardMetadataKey(line string) string {
+	if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, "#") {
+		return ""
+	}
+
+	key, _, found := strings.Cut(line, ":")
+	if !found {
+		return ""
+	}
+
+	return strings.TrimSpace(key)
+}
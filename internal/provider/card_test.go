@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSetCardMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		readme string
+		values map[string]string
+		want   string
+	}{
+		{
+			name:   "no front matter",
+			readme: "# Title\n",
+			values: map[string]string{"sdk": cardString("gradio")},
+			want:   "---\nsdk: \"gradio\"\n---\n# Title\n",
+		},
+		{
+			name:   "empty readme",
+			readme: "",
+			values: map[string]string{"sdk": cardString("gradio")},
+			want:   "---\nsdk: \"gradio\"\n---\n",
+		},
+		{
+			name:   "replace existing key",
+			readme: "---\ntitle: Demo\nsdk: streamlit\n---\n# Title\n",
+			values: map[string]string{"sdk": cardString("gradio")},
+			want:   "---\ntitle: Demo\nsdk: \"gradio\"\n---\n# Title\n",
+		},
+		{
+			name:   "append new keys sorted",
+			readme: "---\ntitle: Demo\n---\nBody",
+			values: map[string]string{"sdk_version": cardString("4.0.0"), "app_port": cardInt(7860)},
+			want:   "---\ntitle: Demo\napp_port: 7860\nsdk_version: \"4.0.0\"\n---\nBody",
+		},
+		{
+			name:   "remove key",
+			readme: "---\ntitle: Demo\napp_port: 8080\n---\nBody",
+			values: map[string]string{"app_port": ""},
+			want:   "---\ntitle: Demo\n---\nBody",
+		},
+		{
+			name:   "remove last key drops front matter",
+			readme: "---\napp_port: 8080\n---\nBody",
+			values: map[string]string{"app_port": ""},
+			want:   "Body",
+		},
+		{
+			name:   "remove missing key",
+			readme: "# Title\n",
+			values: map[string]string{"app_port": ""},
+			want:   "# Title\n",
+		},
+		{
+			name:   "replace block value",
+			readme: "---\ntags:\n- a\n- b\ntitle: Demo\n---\n",
+			values: map[string]string{"tags": cardList([]string{"c"})},
+			want:   "---\ntags:\n- \"c\"\ntitle: Demo\n---\n",
+		},
+		{
+			name:   "replace nested map",
+			readme: "---\nextra_gated_fields:\n  Company: text\n# comment\ntitle: Demo\n---\n",
+			values: map[string]string{"extra_gated_fields": cardMap(map[string]string{"Country": "country"})},
+			want:   "---\nextra_gated_fields:\n  \"Country\": \"country\"\n# comment\ntitle: Demo\n---\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := setCardMetadata(tt.readme, tt.values)
+			if got != tt.want {
+				t.Errorf("setCardMetadata() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitCardMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		readme    string
+		wantLines []string
+		wantBody  string
+	}{
+		{
+			name:     "no front matter",
+			readme:   "# Title\n",
+			wantBody: "# Title\n",
+		},
+		{
+			name:      "front matter",
+			readme:    "---\ntitle: Demo\nsdk: gradio\n---\n# Title\n",
+			wantLines: []string{"title: Demo", "sdk: gradio"},
+			wantBody:  "# Title\n",
+		},
+		{
+			name:      "windows line endings",
+			readme:    "---\r\ntitle: Demo\r\n---\r\nBody",
+			wantLines: []string{"title: Demo"},
+			wantBody:  "Body",
+		},
+		{
+			name:     "empty front matter",
+			readme:   "---\n---\nBody",
+			wantBody: "Body",
+		},
+		{
+			name:     "unterminated front matter",
+			readme:   "---\ntitle: Demo\n",
+			wantBody: "---\ntitle: Demo\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, body := splitCardMetadata(tt.readme)
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("splitCardMetadata() lines = %q, want %q", lines, tt.wantLines)
+			}
+			if body != tt.wantBody {
+				t.Errorf("splitCardMetadata() body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestCardMetadataKey(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "sdk: gradio", want: "sdk"},
+		{line: "tags:", want: "tags"},
+		{line: "app_port : 7860", want: "app_port"},
+		{line: "", want: ""},
+		{line: "  nested: value", want: ""},
+		{line: "\tnested: value", want: ""},
+		{line: "- item", want: ""},
+		{line: "# comment: here", want: ""},
+		{line: "no colon", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got := cardMetadataKey(tt.line)
+			if got != tt.want {
+				t.Errorf("cardMetadataKey(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardList(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{name: "nil", values: nil, want: " []"},
+		{name: "empty", values: []string{}, want: " []"},
+		{name: "one", values: []string{"nlp"}, want: "\n- \"nlp\""},
+		{name: "quoted", values: []string{"a", "3.10"}, want: "\n- \"a\"\n- \"3.10\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cardList(tt.values)
+			if got != tt.want {
+				t.Errorf("cardList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   string
+	}{
+		{name: "nil", values: nil, want: " {}"},
+		{name: "empty", values: map[string]string{}, want: " {}"},
+		{
+			name:   "sorted",
+			values: map[string]string{"Name": "text", "Country": "country"},
+			want:   "\n  \"Country\": \"country\"\n  \"Name\": \"text\"",
+		},
+		{
+			name:   "escaped",
+			values: map[string]string{"I agree: \"yes\"": "checkbox"},
+			want:   "\n  \"I agree: \\\"yes\\\"\": \"checkbox\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cardMap(tt.values)
+			if got != tt.want {
+				t.Errorf("cardMap() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardDataString(t *testing.T) {
+	cardData := map[string]interface{}{
+		"sdk_version":    "4.36.1",
+		"python_version": 3.1,
+		"app_port":       7860.0,
+	}
+
+	tests := []struct {
+		key     string
+		current types.String
+		want    types.String
+	}{
+		{key: "sdk_version", current: types.StringNull(), want: types.StringValue("4.36.1")},
+		{key: "sdk_version", current: types.StringValue("4.0"), want: types.StringValue("4.36.1")},
+		{key: "python_version", current: types.StringValue("3.10"), want: types.StringValue("3.10")},
+		{key: "python_version", current: types.StringValue("3.1"), want: types.StringValue("3.1")},
+		{key: "python_version", current: types.StringValue("3.11"), want: types.StringValue("3.1")},
+		{key: "python_version", current: types.StringNull(), want: types.StringValue("3.1")},
+		{key: "app_port", current: types.StringValue("7860"), want: types.StringValue("7860")},
+		{key: "missing", current: types.StringValue("1"), want: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+" "+tt.current.String(), func(t *testing.T) {
+			got := cardDataString(cardData, tt.key, tt.current)
+			if !got.Equal(tt.want) {
+				t.Errorf("cardDataString(%q, %s) = %s, want %s", tt.key, tt.current, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// collectionItemTypes are the types of items a collection can hold.
+var collectionItemTypes = []string{
+	"model",
+	"dataset",
+	"space",
+	"paper",
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CollectionItemResource{}
+	_ resource.ResourceWithConfigure   = &CollectionItemResource{}
+	_ resource.ResourceWithImportState = &CollectionItemResource{}
+)
+
+// CollectionItemResource defines the resource implementation.
+type CollectionItemResource struct {
+	client *http.Client
+}
+
+// CollectionItemResourceModel describes the resource data model.
+type CollectionItemResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	CollectionSlug types.String `tfsdk:"collection_slug"`
+	ItemType       types.String `tfsdk:"item_type"`
+	ItemID         types.String `tfsdk:"item_id"`
+	Note           types.String `tfsdk:"note"`
+	Position       types.Int64  `tfsdk:"position"`
+}
+
+func (r *CollectionItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_item"
+}
+
+func (r *CollectionItemResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a Space, model, dataset or paper to a collection.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the item within the collection.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"collection_slug": schema.StringAttribute{
+				MarkdownDescription: "Slug of the collection. Moving the item to another collection forces a new item to be created; a new slug for the same collection, after a change of title, does not. A slug that is only known at apply is taken to be that of a renamed collection; if it belongs to another collection, the apply fails and the item has to be replaced.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						collectionChanged,
+						"Moving the item to another collection forces a new item to be created.",
+						"Moving the item to another collection forces a new item to be created.",
+					),
+				},
+			},
+			"item_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the item: `" + strings.Join(collectionItemTypes, "`, `") + "`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(collectionItemTypes...),
+				},
+			},
+			"item_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the item, `owner/name` for repositories or the arXiv ID for papers.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"note": schema.StringAttribute{
+				MarkdownDescription: "Note shown with the item.",
+				Optional:            true,
+			},
+			"position": schema.Int64Attribute{
+				MarkdownDescription: "Position of the item in the collection. Defaults to the end of the collection.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// collectionChanged requires replacing an item when the ID at the end of the
+// collection slug changes. The rest of the slug follows the title. A slug that
+// is not known until apply is most likely that of a renamed collection, so it
+// does not require replacement; Update rejects it if it turns out to belong to
+// another collection.
+func collectionChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() {
+		return
+	}
+
+	resp.RequiresReplace = collectionObjectID(req.StateValue.ValueString()) != collectionObjectID(req.PlanValue.ValueString())
+}
+
+// collectionObjectID returns the ID at the end of a collection slug.
+func collectionObjectID(slug string) string {
+	return slug[strings.LastIndex(slug, "-")+1:]
+}
+
+func (r *CollectionItemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CollectionItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := map[string]interface{}{
+		"item": map[string]string{
+			"type": data.ItemType.ValueString(),
+			"id":   data.ItemID.ValueString(),
+		},
+	}
+	if !data.Note.IsNull() {
+		body["note"] = data.Note.ValueString()
+	}
+
+	respBody, err := sendJSONRequest(r.client, http.MethodPost, collectionURL(data.CollectionSlug.ValueString())+"/items", body, errCollectionNotFound)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to add %s %s to collection, got error: %s", data.ItemType.ValueString(), data.ItemID.ValueString(), err))
+		return
+	}
+
+	var updated collection
+	err = json.Unmarshal(respBody, &updated)
+	if err != nil {
+		resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode add collection item response, got error: %s", err))
+		return
+	}
+
+	for _, item := range updated.Items {
+		if item.Type == data.ItemType.ValueString() && item.ID == data.ItemID.ValueString() {
+			data.ID = types.StringValue(item.ObjectID)
+		}
+	}
+	if data.ID.IsUnknown() {
+		resp.Diagnostics.AddError("Invalid Response", "Unable to find the added item in the add collection item response")
+		return
+	}
+
+	if !data.Position.IsUnknown() {
+		err := r.updateItem(data)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to move collection item, got error: %s", err))
+			return
+		}
+	}
+
+	err = r.readItem(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read created collection item, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.readItem(ctx, data)
+	if errors.Is(err, errCollectionNotFound) {
+		log.Printf("[DEBUG] Collection item %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read collection item, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state CollectionItemResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A slug that was unknown at plan time did not force replacement, which
+	// is only right if it still belongs to the same collection.
+	if collectionObjectID(state.CollectionSlug.ValueString()) != collectionObjectID(data.CollectionSlug.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("collection_slug"),
+			"Collection Changed",
+			fmt.Sprintf("The item was planned to stay in collection %s, but collection_slug now refers to %s. Replace the item to move it to another collection, e.g. with terraform apply -replace.", state.CollectionSlug.ValueString(), data.CollectionSlug.ValueString()),
+		)
+		return
+	}
+
+	err := r.updateItem(data)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update collection item, got error: %s", err))
+		return
+	}
+
+	err = r.readItem(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated collection item, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CollectionItemResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := sendJSONRequest(r.client, http.MethodDelete, r.itemURL(data), nil, errCollectionNotFound)
+	if err != nil && !errors.Is(err, errCollectionNotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to remove item from collection, got error: %s", err))
+		return
+	}
+}
+
+// itemURL returns the API URL of the item.
+func (r *CollectionItemResource) itemURL(data *CollectionItemResourceModel) string {
+	return fmt.Sprintf("%s/items/%s", collectionURL(data.CollectionSlug.ValueString()), data.ID.ValueString())
+}
+
+// updateItem sets the note and, when it is known, the position of the item.
+func (r *CollectionItemResource) updateItem(data *CollectionItemResourceModel) error {
+	body := map[string]interface{}{
+		"note": data.Note.ValueString(),
+	}
+	if !data.Position.IsUnknown() {
+		body["position"] = data.Position.ValueInt64()
+	}
+
+	_, err := sendJSONRequest(r.client, http.MethodPatch, r.itemURL(data), body, errCollectionNotFound)
+
+	return err
+}
+
+// readItem refreshes data from the items of the collection. It returns
+// errCollectionNotFound when the item is no longer in the collection.
+func (r *CollectionItemResource) readItem(ctx context.Context, data *CollectionItemResourceModel) error {
+	pages := newPaginator(r.client, collectionURL(data.CollectionSlug.ValueString()), -1)
+	for pages.HasNext() {
+		items, err := pages.Next(ctx)
+		if errors.Is(err, errPageNotFound) {
+			return errCollectionNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, raw := range items {
+			var item collectionItem
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return err
+			}
+
+			if item.ObjectID != data.ID.ValueString() {
+				continue
+			}
+
+			data.ItemType = types.StringValue(item.Type)
+			data.ItemID = types.StringValue(item.ID)
+			data.Position = types.Int64Value(item.Position)
+
+			// note is not computed, so a missing note stays null.
+			if item.Note != nil && item.Note.Text != "" {
+				data.Note = types.StringValue(item.Note.Text)
+			} else if !data.Note.IsNull() {
+				data.Note = types.StringValue("")
+			}
+
+			return nil
+		}
+	}
+
+	return errCollectionNotFound
+}
+
+func (r *CollectionItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.TrimSpace(req.ID)
+
+	idx := strings.LastIndex(id, "/")
+	if strings.Count(id, "/") != 2 || idx == len(id)-1 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected collection_slug/item_id, e.g. namespace/title-id/item, got: %s", req.ID),
+		)
+		return
+	}
+
+	// The remaining attributes are filled in by Read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_slug"), id[:idx])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id[idx+1:])...)
+}
+
+func NewCollectionItemResource() resource.Resource {
+	return &CollectionItemResource{}
+}
@@ -0,0 +1,404 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// errCollectionNotFound is returned when a collection, or an item of it, does
+// not exist (anymore).
+var errCollectionNotFound = errors.New("collection not found")
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CollectionResource{}
+	_ resource.ResourceWithConfigure   = &CollectionResource{}
+	_ resource.ResourceWithImportState = &CollectionResource{}
+	_ resource.ResourceWithModifyPlan  = &CollectionResource{}
+)
+
+// CollectionResource defines the resource implementation.
+type CollectionResource struct {
+	client *http.Client
+}
+
+// CollectionResourceModel describes the resource data model.
+type CollectionResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Slug        types.String `tfsdk:"slug"`
+	Title       types.String `tfsdk:"title"`
+	Namespace   types.String `tfsdk:"namespace"`
+	Description types.String `tfsdk:"description"`
+	Private     types.Bool   `tfsdk:"private"`
+	Theme       types.String `tfsdk:"theme"`
+	URL         types.String `tfsdk:"url"`
+}
+
+// collection is a collection as returned by the collections API.
+type collection struct {
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Owner       struct {
+		Name string `json:"name"`
+	} `json:"owner"`
+	Private bool             `json:"private"`
+	Theme   string           `json:"theme"`
+	Items   []collectionItem `json:"items"`
+}
+
+// collectionItem is an item of a collection.
+type collectionItem struct {
+	ObjectID string `json:"_id"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Position int64  `json:"position"`
+	Note     *struct {
+		Text string `json:"text"`
+	} `json:"note"`
+}
+
+func (r *CollectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a collection of Spaces, models, datasets and papers. Add items with `huggingface-spaces_collection_item`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Slug of the collection.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "Slug of the collection, `namespace/title-id`. It changes with the title.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the collection.",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "User or organization that owns the collection. Defaults to the user of the token. Changing it forces a new collection to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the collection.",
+				Optional:            true,
+			},
+			"private": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"theme": schema.StringAttribute{
+				MarkdownDescription: "Color theme of the collection on the Hub.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of the collection on the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CollectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on creation or when destroying.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, state CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The slug is derived from the title.
+	if config.Title.IsUnknown() || !config.Title.Equal(state.Title) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("slug"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("url"), types.StringUnknown())...)
+	}
+}
+
+func (r *CollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Namespace.IsNull() || data.Namespace.IsUnknown() {
+		name, err := fetchWhoamiName(r.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up the user of the token, got error: %s", err))
+			return
+		}
+		data.Namespace = types.StringValue(name)
+	}
+
+	body := map[string]interface{}{
+		"title":     data.Title.ValueString(),
+		"namespace": data.Namespace.ValueString(),
+		"private":   data.Private.ValueBool(),
+	}
+	if !data.Description.IsNull() {
+		body["description"] = data.Description.ValueString()
+	}
+
+	respBody, err := sendJSONRequest(r.client, http.MethodPost, "https://huggingface.co/api/collections", body, errCollectionNotFound)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create collection, got error: %s", err))
+		return
+	}
+
+	var created collection
+	err = json.Unmarshal(respBody, &created)
+	if err != nil {
+		resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode create collection response, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(created.Slug)
+
+	// The theme can only be set once the collection exists.
+	if !data.Theme.IsNull() && !data.Theme.IsUnknown() {
+		theme := map[string]interface{}{
+			"theme": data.Theme.ValueString(),
+		}
+
+		_, err := sendJSONRequest(r.client, http.MethodPatch, collectionURL(created.Slug), theme, errCollectionNotFound)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to set collection theme, got error: %s", err))
+			return
+		}
+	}
+
+	err = r.readCollection(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read created collection, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.readCollection(data)
+	if errors.Is(err, errCollectionNotFound) {
+		log.Printf("[DEBUG] Collection %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read collection, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state CollectionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := map[string]interface{}{
+		"title":       data.Title.ValueString(),
+		"description": data.Description.ValueString(),
+	}
+	if !data.Private.IsUnknown() {
+		body["private"] = data.Private.ValueBool()
+	}
+	if !data.Theme.IsUnknown() {
+		body["theme"] = data.Theme.ValueString()
+	}
+
+	_, err := sendJSONRequest(r.client, http.MethodPatch, collectionURL(state.ID.ValueString()), body, errCollectionNotFound)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update collection, got error: %s", err))
+		return
+	}
+
+	// Collections are found by the ID at the end of the slug, so the old slug
+	// still resolves after a change of title.
+	data.ID = state.ID
+
+	err = r.readCollection(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated collection, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := sendJSONRequest(r.client, http.MethodDelete, collectionURL(data.ID.ValueString()), nil, errCollectionNotFound)
+	if err != nil && !errors.Is(err, errCollectionNotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete collection, got error: %s", err))
+		return
+	}
+}
+
+// readCollection refreshes data from the Hub.
+func (r *CollectionResource) readCollection(data *CollectionResourceModel) error {
+	c, err := fetchCollection(r.client, data.ID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.ID = types.StringValue(c.Slug)
+	data.Slug = types.StringValue(c.Slug)
+	data.Title = types.StringValue(c.Title)
+	data.Namespace = types.StringValue(c.Owner.Name)
+	data.Private = types.BoolValue(c.Private)
+	data.Theme = optionalString(c.Theme)
+	data.URL = types.StringValue(fmt.Sprintf("https://huggingface.co/collections/%s", c.Slug))
+
+	// description is not computed, so an empty description stays null.
+	if c.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(c.Description)
+	}
+
+	return nil
+}
+
+// collectionURL returns the API URL of a collection.
+func collectionURL(slug string) string {
+	return fmt.Sprintf("https://huggingface.co/api/collections/%s", slug)
+}
+
+// fetchCollection returns a collection and its items.
+func fetchCollection(client *http.Client, slug string) (*collection, error) {
+	url := collectionURL(slug)
+	log.Printf("[DEBUG] Requesting URL: %s", url)
+
+	httpResp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, errCollectionNotFound
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var c collection
+	err = json.NewDecoder(httpResp.Body).Decode(&c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	slug := strings.TrimSpace(req.ID)
+	slug = strings.TrimPrefix(slug, "https://huggingface.co/collections/")
+
+	if strings.Count(slug, "/") != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the slug of the collection, namespace/title-id, got: %s", req.ID),
+		)
+		return
+	}
+
+	// The remaining attributes are filled in by Read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), slug)...)
+}
+
+func NewCollectionResource() resource.Resource {
+	return &CollectionResource{}
+}
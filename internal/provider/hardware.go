@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// hardwareCatalogURL lists the hardware flavors currently offered for Spaces.
+const hardwareCatalogURL = "https://huggingface.co/api/spaces/hardware"
+
+// freeHardwareFlavor is the default hardware of a Space. It cannot be given a
+// custom sleep time.
+const freeHardwareFlavor = "cpu-basic"
+
+// knownHardwareFlavors are the hardware flavors known at release time. New
+// flavors are accepted when they appear in the live catalog.
+var knownHardwareFlavors = []string{
+	"cpu-basic",
+	"cpu-upgrade",
+	"cpu-xl",
+	"zero-a10g",
+	"t4-small",
+	"t4-medium",
+	"l4x1",
+	"l4x4",
+	"l40sx1",
+	"l40sx4",
+	"l40sx8",
+	"a10g-small",
+	"a10g-large",
+	"a10g-largex2",
+	"a10g-largex4",
+	"a100-large",
+	"h100",
+	"h100x8",
+}
+
+// devModeUnsupportedHardware are the hardware flavors on which dev mode cannot
+// be enabled.
+var devModeUnsupportedHardware = []string{
+	"cpu-basic",
+	"zero-a10g",
+}
+
+// storageTiers are the persistent storage tiers, from smallest to largest.
+var storageTiers = []string{
+	"small",
+	"medium",
+	"large",
+}
+
+// minSleepTime is the shortest sleep time, in seconds, the Hub accepts.
+// A sleep time of -1 keeps the Space running forever.
+const minSleepTime = 300
+
+// isKnownHardwareFlavor reports whether flavor is in knownHardwareFlavors.
+func isKnownHardwareFlavor(flavor string) bool {
+	for _, known := range knownHardwareFlavors {
+		if flavor == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// supportsDevMode reports whether dev mode can be enabled on flavor.
+func supportsDevMode(flavor string) bool {
+	for _, unsupported := range devModeUnsupportedHardware {
+		if flavor == unsupported {
+			return false
+		}
+	}
+
+	return true
+}
+
+// storageTierIndex returns the position of tier in storageTiers, or -1 for no
+// storage.
+func storageTierIndex(tier string) int {
+	for i, known := range storageTiers {
+		if tier == known {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// hardwareFlavor is an entry of the hardware catalog.
+type hardwareFlavor struct {
+	Name        string `json:"name"`
+	PrettyName  string `json:"prettyName"`
+	CPU         string `json:"cpu"`
+	RAM         string `json:"ram"`
+	Accelerator *struct {
+		Type     string `json:"type"`
+		Model    string `json:"model"`
+		Quantity int64  `json:"quantity"`
+		VRAM     string `json:"vram"`
+	} `json:"accelerator"`
+	UnitCostUSD float64 `json:"unitCostUSD"`
+	UnitLabel   string  `json:"unitLabel"`
+}
+
+// hourlyPrice returns the price of the flavor per hour in USD.
+func (f hardwareFlavor) hourlyPrice() float64 {
+	if f.UnitLabel == "minute" {
+		return f.UnitCostUSD * 60
+	}
+
+	return f.UnitCostUSD
+}
+
+// fetchHardwareFlavors returns the live hardware catalog.
+func fetchHardwareFlavors(client *http.Client) ([]hardwareFlavor, error) {
+	httpResp, err := client.Get(hardwareCatalogURL)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var flavors []hardwareFlavor
+	err = json.NewDecoder(httpResp.Body).Decode(&flavors)
+	if err != nil {
+		return nil, err
+	}
+
+	return flavors, nil
+}
+
+// fetchHardwareFlavorNames returns the names of the hardware flavors in the
+// live catalog.
+func fetchHardwareFlavorNames(client *http.Client) ([]string, error) {
+	flavors, err := fetchHardwareFlavors(client)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, flavor := range flavors {
+		names = append(names, flavor.Name)
+	}
+
+	return names, nil
+}
+
+// parseMemoryGB parses sizes such as "24 GB" or "80GB" into gigabytes.
+func parseMemoryGB(size string) (float64, bool) {
+	size = strings.TrimSpace(size)
+	end := strings.IndexFunc(size, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end == -1 {
+		end = len(size)
+	}
+
+	value, err := strconv.ParseFloat(size[:end], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(size[end:])) {
+	case "", "GB", "GIB", "G":
+		return value, true
+	case "TB", "TIB", "T":
+		return value * 1024, true
+	case "MB", "MIB", "M":
+		return value / 1024, true
+	}
+
+	return 0, false
+}
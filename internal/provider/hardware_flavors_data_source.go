@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &HardwareFlavorsDataSource{}
+
+// HardwareFlavorsDataSource defines the data source implementation.
+type HardwareFlavorsDataSource struct {
+	client *http.Client
+}
+
+// HardwareFlavorsDataSourceModel describes the data source data model.
+type HardwareFlavorsDataSourceModel struct {
+	Flavors []HardwareFlavorModel `tfsdk:"flavors"`
+}
+
+// HardwareFlavorModel describes a single hardware flavor.
+type HardwareFlavorModel struct {
+	Name                types.String  `tfsdk:"name"`
+	PrettyName          types.String  `tfsdk:"pretty_name"`
+	CPU                 types.String  `tfsdk:"cpu"`
+	RAM                 types.String  `tfsdk:"ram"`
+	Accelerator         types.String  `tfsdk:"accelerator"`
+	AcceleratorCount    types.Int64   `tfsdk:"accelerator_count"`
+	AcceleratorMemory   types.String  `tfsdk:"accelerator_memory"`
+	AcceleratorMemoryGB types.Float64 `tfsdk:"accelerator_memory_gb"`
+	HourlyPrice         types.Float64 `tfsdk:"hourly_price"`
+}
+
+func (d *HardwareFlavorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hardware_flavors"
+}
+
+func (d *HardwareFlavorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the hardware flavors Spaces can run on, with their resources and pricing.",
+		Attributes: map[string]schema.Attribute{
+			"flavors": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Flavor name, as used in the `hardware` attribute of `huggingface-spaces_space`.",
+							Computed:            true,
+						},
+						"pretty_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"cpu": schema.StringAttribute{
+							Computed: true,
+						},
+						"ram": schema.StringAttribute{
+							Computed: true,
+						},
+						"accelerator": schema.StringAttribute{
+							MarkdownDescription: "Accelerator model, or null for CPU-only flavors.",
+							Computed:            true,
+						},
+						"accelerator_count": schema.Int64Attribute{
+							Computed: true,
+						},
+						"accelerator_memory": schema.StringAttribute{
+							MarkdownDescription: "Memory of each accelerator as reported by the Hub, e.g. `24 GB`.",
+							Computed:            true,
+						},
+						"accelerator_memory_gb": schema.Float64Attribute{
+							MarkdownDescription: "Memory of each accelerator in gigabytes.",
+							Computed:            true,
+						},
+						"hourly_price": schema.Float64Attribute{
+							MarkdownDescription: "Price per hour in USD.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HardwareFlavorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HardwareFlavorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HardwareFlavorsDataSourceModel
+
+	flavors, err := fetchHardwareFlavors(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read hardware flavors, got error: %s", err))
+		return
+	}
+
+	data.Flavors = []HardwareFlavorModel{}
+	for _, flavor := range flavors {
+		model := HardwareFlavorModel{
+			Name:                types.StringValue(flavor.Name),
+			PrettyName:          optionalString(flavor.PrettyName),
+			CPU:                 optionalString(flavor.CPU),
+			RAM:                 optionalString(flavor.RAM),
+			Accelerator:         types.StringNull(),
+			AcceleratorCount:    types.Int64Null(),
+			AcceleratorMemory:   types.StringNull(),
+			AcceleratorMemoryGB: types.Float64Null(),
+			HourlyPrice:         types.Float64Value(flavor.hourlyPrice()),
+		}
+
+		if flavor.Accelerator != nil {
+			model.Accelerator = optionalString(flavor.Accelerator.Model)
+			model.AcceleratorCount = types.Int64Value(flavor.Accelerator.Quantity)
+			model.AcceleratorMemory = optionalString(flavor.Accelerator.VRAM)
+
+			if memory, ok := parseMemoryGB(flavor.Accelerator.VRAM); ok {
+				model.AcceleratorMemoryGB = types.Float64Value(memory)
+			}
+		}
+
+		data.Flavors = append(data.Flavors, model)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// optionalString maps an empty string to null.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+func NewHardwareFlavorsDataSource() datasource.DataSource {
+	return &HardwareFlavorsDataSource{}
+}
@@ -0,0 +1,57 @@
+package provider
+
+import "testing"
+
+func TestParseMemoryGB(t *testing.T) {
+	tests := []struct {
+		size   string
+		want   float64
+		wantOK bool
+	}{
+		{size: "24 GB", want: 24, wantOK: true},
+		{size: "80GB", want: 80, wantOK: true},
+		{size: " 16 GiB ", want: 16, wantOK: true},
+		{size: "46", want: 46, wantOK: true},
+		{size: "0.5 gb", want: 0.5, wantOK: true},
+		{size: "1 TB", want: 1024, wantOK: true},
+		{size: "512 MB", want: 0.5, wantOK: true},
+		{size: "", wantOK: false},
+		{size: "GB", wantOK: false},
+		{size: "24 PB", wantOK: false},
+		{size: "lots", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, ok := parseMemoryGB(tt.size)
+			if ok != tt.wantOK {
+				t.Fatalf("parseMemoryGB(%q) ok = %t, want %t", tt.size, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("parseMemoryGB(%q) = %v, want %v", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorageTierIndex(t *testing.T) {
+	tests := []struct {
+		tier string
+		want int
+	}{
+		{tier: "", want: -1},
+		{tier: "small", want: 0},
+		{tier: "medium", want: 1},
+		{tier: "large", want: 2},
+		{tier: "huge", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tier, func(t *testing.T) {
+			got := storageTierIndex(tt.tier)
+			if got != tt.want {
+				t.Errorf("storageTierIndex(%q) = %d, want %d", tt.tier, got, tt.want)
+			}
+		})
+	}
+}
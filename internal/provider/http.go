@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// sendJSONRequest sends a request with a JSON body, if any, and returns the
+// response body. It returns errNotFound when the API responds with 404.
+func sendJSONRequest(client *http.Client, method, url string, body map[string]interface{}, errNotFound error) ([]byte, error) {
+	var reqBody string
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = string(encoded)
+	}
+	log.Printf("[DEBUG] %s %s Request Body: %s", method, url, reqBody)
+
+	httpReq, err := http.NewRequest(method, url, strings.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// errPageNotFound is returned by paginator.Next when the listing does not
+// exist, for example because the repository it belongs to was deleted.
+var errPageNotFound = errors.New("page not found")
+
+// paginator lazily walks a paginated Hub listing. Each call to Next fetches
+// one page and follows the rel="next" target of its Link header, until the
+// last page or the maximum number of items has been reached. Pages are either
+// a JSON array of items or an object holding them in "items".
+//
+//	pages := newPaginator(client, url, -1)
+//	for pages.HasNext() {
+//		items, err := pages.Next(ctx)
+//		...
+//	}
+type paginator struct {
+	client *http.Client
+	next   string
+	max    int
+	seen   int
+}
+
+// newPaginator returns a paginator starting at firstURL that stops after max
+// items. A negative max reads every page.
+func newPaginator(client *http.Client, firstURL string, max int) *paginator {
+	return &paginator{
+		client: client,
+		next:   firstURL,
+		max:    max,
+	}
+}
+
+// HasNext reports whether there are more items to read.
+func (p *paginator) HasNext() bool {
+	return p.next != "" && (p.max < 0 || p.seen < p.max)
+}
+
+// Next fetches the next page and returns its items, trimmed so that no more
+// than max items are returned in total. It fails if ctx is done, for example
+// when Terraform is interrupted.
+func (p *paginator) Next(ctx context.Context) ([]json.RawMessage, error) {
+	if !p.HasNext() {
+		return nil, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Requesting URL: %s", p.next)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.next, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, errPageNotFound
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var page json.RawMessage
+	err = json.NewDecoder(httpResp.Body).Decode(&page)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := pageItems(page)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.max >= 0 && p.seen+len(items) > p.max {
+		items = items[:p.max-p.seen]
+	}
+
+	// An empty page, or one linking to itself, would never end the listing.
+	next := nextPageURL(httpResp.Header.Get("Link"))
+	if len(items) == 0 || next == p.next {
+		next = ""
+	}
+
+	p.seen += len(items)
+	p.next = next
+
+	return items, nil
+}
+
+// pageItems returns the items of a page, either a JSON array or an object
+// with an "items" array.
+func pageItems(page json.RawMessage) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+
+	if bytes.HasPrefix(bytes.TrimSpace(page), []byte("{")) {
+		var object struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(page, &object); err != nil {
+			return nil, err
+		}
+
+		return object.Items, nil
+	}
+
+	if err := json.Unmarshal(page, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// nextPageURL returns the rel="next" target of a Link header, or an empty
+// string on the last page.
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, params, found := strings.Cut(part, ";")
+		if !found {
+			continue
+		}
+
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if key == "rel" && strings.Trim(value, `"`) == "next" {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			name: "empty",
+			link: "",
+			want: "",
+		},
+		{
+			name: "next",
+			link: `<https://huggingface.co/api/spaces?cursor=abc>; rel="next"`,
+			want: "https://huggingface.co/api/spaces?cursor=abc",
+		},
+		{
+			name: "unquoted rel",
+			link: `<https://huggingface.co/api/spaces?cursor=abc>; rel=next`,
+			want: "https://huggingface.co/api/spaces?cursor=abc",
+		},
+		{
+			name: "several links",
+			link: `<https://huggingface.co/api/spaces?cursor=a>; rel="prev", <https://huggingface.co/api/spaces?cursor=b>; rel="next"`,
+			want: "https://huggingface.co/api/spaces?cursor=b",
+		},
+		{
+			name: "extra params",
+			link: `<https://huggingface.co/api/spaces?cursor=b>; type="application/json"; rel="next"`,
+			want: "https://huggingface.co/api/spaces?cursor=b",
+		},
+		{
+			name: "no next",
+			link: `<https://huggingface.co/api/spaces?cursor=a>; rel="prev"`,
+			want: "",
+		},
+		{
+			name: "malformed",
+			link: `https://huggingface.co/api/spaces?cursor=a`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextPageURL(tt.link)
+			if got != tt.want {
+				t.Errorf("nextPageURL(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+// testPage is a page served by testPagesClient.
+type testPage struct {
+	body string
+	next string
+}
+
+// testPagesClient returns a client that serves pages keyed by the "page"
+// query parameter, linking each to the next one.
+func testPagesClient(pages map[string]testPage) *http.Client {
+	return &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			page, ok := pages[req.URL.Query().Get("page")]
+			if !ok {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader("{}")),
+					Request:    req,
+				}, nil
+			}
+
+			header := http.Header{}
+			if page.next != "" {
+				header.Set("Link", `<https://huggingface.co/api/test?page=`+page.next+`>; rel="next"`)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader(page.body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+}
+
+func TestPaginator(t *testing.T) {
+	pages := map[string]testPage{
+		"1":     {body: `[1, 2, 3]`, next: "2"},
+		"2":     {body: `[4, 5]`, next: "3"},
+		"3":     {body: `[6]`},
+		"empty": {body: `[]`, next: "empty"},
+		"self":  {body: `[1]`, next: "self"},
+		"obj":   {body: `{"title": "t", "items": [1, 2]}`, next: "3"},
+	}
+
+	tests := []struct {
+		name    string
+		first   string
+		max     int
+		want    []string
+		wantErr error
+	}{
+		{name: "all pages", first: "1", max: -1, want: []string{"1", "2", "3", "4", "5", "6"}},
+		{name: "max within first page", first: "1", max: 2, want: []string{"1", "2"}},
+		{name: "max at page boundary", first: "1", max: 3, want: []string{"1", "2", "3"}},
+		{name: "max across pages", first: "1", max: 4, want: []string{"1", "2", "3", "4"}},
+		{name: "max beyond items", first: "1", max: 10, want: []string{"1", "2", "3", "4", "5", "6"}},
+		{name: "max zero", first: "1", max: 0, want: nil},
+		{name: "empty page with next link", first: "empty", max: -1, want: nil},
+		{name: "page linking to itself", first: "self", max: -1, want: []string{"1"}},
+		{name: "object page", first: "obj", max: -1, want: []string{"1", "2", "6"}},
+		{name: "not found", first: "missing", max: -1, wantErr: errPageNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPaginator(testPagesClient(pages), "https://huggingface.co/api/test?page="+tt.first, tt.max)
+
+			var got []string
+			for requests := 0; p.HasNext(); requests++ {
+				if requests > len(pages) {
+					t.Fatalf("paginator did not stop after %d requests", requests)
+				}
+
+				items, err := p.Next(context.Background())
+				if err != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("Next() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+
+				for _, item := range items {
+					got = append(got, string(item))
+				}
+			}
+
+			if tt.wantErr != nil {
+				t.Fatalf("Next() error = nil, want %v", tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginatorCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := newPaginator(testPagesClient(map[string]testPage{"1": {body: `[1]`}}), "https://huggingface.co/api/test?page=1", -1)
+
+	if _, err := p.Next(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Next() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// privateState is the provider-defined private state of a resource, as passed
+// to and returned from its methods.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// privateFlag reports whether a flag is set in the private state of a
+// resource. Flags that were never set are false.
+func privateFlag(ctx context.Context, private privateState, key string) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, key)
+
+	return string(value) == "true", diags
+}
+
+// setPrivateFlag sets or clears a flag in the private state of a resource.
+func setPrivateFlag(ctx context.Context, private privateState, key string, value bool) diag.Diagnostics {
+	return private.SetKey(ctx, key, []byte(strconv.FormatBool(value)))
+}
@@ -79,12 +79,26 @@ func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 func (p *HuggingFaceSpacesProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSpaceResource,
+		NewSpaceDomainResource,
+		NewModelResource,
+		NewDatasetResource,
+		NewRepoResource,
+		NewRepoBranchResource,
+		NewRepoTagResource,
+		NewRepoAccessRequestResource,
+		NewCollectionResource,
+		NewCollectionItemResource,
+		NewWebhookResource,
 	}
 }
 
 func (p *HuggingFaceSpacesProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSpaceDataSource,
+		NewSpacesDataSource,
+		NewSpaceRuntimeDataSource,
+		NewHardwareFlavorsDataSource,
+		NewWhoamiDataSource,
 	}
 }
 
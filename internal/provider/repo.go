@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// errRepoNotFound is returned when a repository does not exist (anymore).
+var errRepoNotFound = errors.New("repository not found")
+
+// repoTypes are the repository types of the Hub.
+var repoTypes = []string{
+	"model",
+	"dataset",
+	"space",
+}
+
+// gatedModes are the values of the gated setting of a repository. "false"
+// disables gating.
+var gatedModes = []string{
+	"false",
+	"auto",
+	"manual",
+}
+
+// gatedFieldTypes are the types of the fields of an access request form.
+var gatedFieldTypes = []string{
+	"text",
+	"checkbox",
+	"date_picker",
+	"country",
+}
+
+// createRepo creates a repository of the given type and returns its canonical
+// ID. The repository is created in the namespace of the token's user when
+// organization is not set. Type-specific settings, such as the SDK of a
+// Space, are passed in options.
+func createRepo(client *http.Client, repoType, name string, organization types.String, private bool, options map[string]interface{}) (string, error) {
+	url := "https://huggingface.co/api/repos/create"
+
+	body := map[string]interface{}{
+		"type":    repoType,
+		"name":    name,
+		"private": private,
+	}
+	if !organization.IsNull() && !organization.IsUnknown() {
+		body["organization"] = organization.ValueString()
+	}
+	for key, value := range options {
+		body[key] = value
+	}
+
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("[DEBUG] Create Repo Request Body: %s", reqBody)
+
+	httpResp, err := client.Post(url, "application/json", strings.NewReader(string(reqBody)))
+	if err != nil {
+		return "", err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return "", fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	var responseData map[string]interface{}
+	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] Create Repo Response: %+v", responseData)
+
+	repoID, ok := responseData["name"].(string)
+	if !ok {
+		return "", fmt.Errorf("missing name in response")
+	}
+
+	return repoID, nil
+}
+
+// moveRepo renames a repository or moves it to another namespace.
+func moveRepo(client *http.Client, repoType, fromRepo, toRepo string) error {
+	url := "https://huggingface.co/api/repos/move"
+
+	reqBody := fmt.Sprintf(`{"fromRepo": "%s", "toRepo": "%s", "type": "%s"}`, fromRepo, toRepo, repoType)
+	log.Printf("[DEBUG] Move Repo Request Body: %s", reqBody)
+
+	httpResp, err := client.Post(url, "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Move Repo Response Body: %s", string(respBody))
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// updateRepoSettings updates settings of a repository such as its visibility
+// ("private") or gating ("gated").
+func updateRepoSettings(client *http.Client, repoType, repoID string, settings map[string]interface{}) error {
+	url := fmt.Sprintf("https://huggingface.co/api/%s/%s/settings", repoURLPrefix(repoType), repoID)
+
+	reqBody, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Update Repo Settings Request Body: %s", reqBody)
+
+	httpReq, err := http.NewRequest(http.MethodPut, url, strings.NewReader(string(reqBody)))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Update Repo Settings Response Body: %s", string(respBody))
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// deleteRepo deletes a repository. Deleting a repository that no longer
+// exists is not an error.
+func deleteRepo(client *http.Client, repoType, name string, organization types.String) error {
+	url := "https://huggingface.co/api/repos/delete"
+
+	reqBody := fmt.Sprintf(`{"type": "%s", "name": "%s", "organization": %s}`, repoType, name, jsonStringOrNull(organization))
+
+	httpReq, err := http.NewRequest(http.MethodDelete, url, strings.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// fetchRepo returns the details of a repository, including its card metadata.
+func fetchRepo(client *http.Client, repoType, repoID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("https://huggingface.co/api/%s/%s", repoURLPrefix(repoType), repoID)
+	log.Printf("[DEBUG] Requesting URL: %s", url)
+
+	httpResp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, errRepoNotFound
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var repo map[string]interface{}
+	err = json.NewDecoder(httpResp.Body).Decode(&repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// repoGated returns the gated setting of a repository as one of gatedModes.
+// The Hub reports disabled gating as the boolean false.
+func repoGated(repo map[string]interface{}) types.String {
+	if mode, ok := repo["gated"].(string); ok {
+		return types.StringValue(mode)
+	}
+
+	return types.StringValue("false")
+}
+
+// gatedSetting converts a gated attribute to the value the settings endpoint
+// expects.
+func gatedSetting(gated types.String) interface{} {
+	if gated.ValueString() == "false" {
+		return false
+	}
+
+	return gated.ValueString()
+}
+
+// parseRepoImportID normalises an import ID, either owner/name or the website
+// URL of the repository, to the canonical owner/name ID.
+func parseRepoImportID(repoType, importID string) (string, error) {
+	id := strings.TrimSpace(importID)
+	id = strings.TrimPrefix(id, "https://")
+	id = strings.TrimPrefix(id, "http://")
+	id = strings.TrimPrefix(id, "www.")
+	id = strings.TrimSuffix(id, "/")
+
+	if rest, found := strings.CutPrefix(id, "huggingface.co/"); found {
+		if repoType != "model" {
+			rest, found = strings.CutPrefix(rest, repoURLPrefix(repoType)+"/")
+			if !found {
+				return "", fmt.Errorf("not a %s URL", repoType)
+			}
+		}
+
+		// Ignore anything after owner/name, such as /tree/main.
+		parts := strings.Split(rest, "/")
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return "", fmt.Errorf("missing owner or name")
+		}
+
+		return parts[0] + "/" + parts[1], nil
+	}
+
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("missing owner or name")
+	}
+
+	return id, nil
+}
+
+// parseTypedRepoImportID parses an import ID that also identifies the type of
+// the repository, either type/owner/name or the website URL of the
+// repository.
+func parseTypedRepoImportID(importID string) (string, string, error) {
+	id := strings.TrimSpace(importID)
+
+	if strings.Contains(id, "huggingface.co/") {
+		_, path, _ := strings.Cut(id, "huggingface.co/")
+
+		repoType := "model"
+		for _, candidate := range []string{"dataset", "space"} {
+			if strings.HasPrefix(path, repoURLPrefix(candidate)+"/") {
+				repoType = candidate
+			}
+		}
+
+		repoID, err := parseRepoImportID(repoType, id)
+
+		return repoType, repoID, err
+	}
+
+	repoType, repoID, found := strings.Cut(id, "/")
+	if !found || !isRepoType(repoType) {
+		return "", "", fmt.Errorf("missing repository type")
+	}
+
+	repoID, err := parseRepoImportID(repoType, repoID)
+
+	return repoType, repoID, err
+}
+
+// isRepoType reports whether repoType is in repoTypes.
+func isRepoType(repoType string) bool {
+	for _, known := range repoTypes {
+		if repoType == known {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,319 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// errAccessRequestNotFound is returned when a user has no access request for
+// a repository.
+var errAccessRequestNotFound = errors.New("access request not found")
+
+// accessRequestStatuses are the statuses an access request can be listed
+// under.
+var accessRequestStatuses = []string{
+	"accepted",
+	"rejected",
+	"pending",
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoAccessRequestResource{}
+	_ resource.ResourceWithConfigure   = &RepoAccessRequestResource{}
+	_ resource.ResourceWithImportState = &RepoAccessRequestResource{}
+)
+
+// RepoAccessRequestResource defines the resource implementation.
+type RepoAccessRequestResource struct {
+	client *http.Client
+}
+
+// RepoAccessRequestResourceModel describes the resource data model.
+type RepoAccessRequestResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	RepoType        types.String `tfsdk:"repo_type"`
+	RepoID          types.String `tfsdk:"repo_id"`
+	User            types.String `tfsdk:"user"`
+	Status          types.String `tfsdk:"status"`
+	RejectionReason types.String `tfsdk:"rejection_reason"`
+}
+
+func (r *RepoAccessRequestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_access_request"
+}
+
+func (r *RepoAccessRequestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Accepts or rejects the access of a user to a gated repository. Users are granted access even if they have not requested it. Destroying the resource moves the request back to pending, which revokes access.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the access request, `type/owner/name/user`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the repository: `model`, `dataset` or `space`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "ID (`owner/name`) of the gated repository.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Username of the user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the access request: `accepted` (the default) or `rejected`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("accepted"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("accepted", "rejected"),
+				},
+			},
+			"rejection_reason": schema.StringAttribute{
+				MarkdownDescription: "Reason shown to the user when the request is rejected.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *RepoAccessRequestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RepoAccessRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepoAccessRequestResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.handleRequest(data)
+
+	// Users who have not requested access can only be granted it.
+	if errors.Is(err, errAccessRequestNotFound) && data.Status.ValueString() == "accepted" {
+		err = r.postAccessRequest(data, "grant", fmt.Sprintf(`{"user": %s}`, jsonStringOrNull(data.User)))
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to set access request of %s to %s, got error: %s", data.User.ValueString(), data.Status.ValueString(), err))
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", data.RepoType.ValueString(), data.RepoID.ValueString(), data.User.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoAccessRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepoAccessRequestResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := fetchAccessRequestStatus(ctx, r.client, data.RepoType.ValueString(), data.RepoID.ValueString(), data.User.ValueString())
+	if errors.Is(err, errAccessRequestNotFound) {
+		log.Printf("[DEBUG] Access request %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access request, got error: %s", err))
+		return
+	}
+
+	// A request moved back to pending outside of Terraform shows up as a
+	// change to apply again.
+	data.Status = types.StringValue(status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoAccessRequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RepoAccessRequestResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.handleRequest(data)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update access request of %s, got error: %s", data.User.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoAccessRequestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepoAccessRequestResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqBody := fmt.Sprintf(`{"user": %s, "status": "pending"}`, jsonStringOrNull(data.User))
+
+	err := r.postAccessRequest(data, "handle", reqBody)
+	if err != nil && !errors.Is(err, errAccessRequestNotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to revoke access of %s, got error: %s", data.User.ValueString(), err))
+		return
+	}
+}
+
+// handleRequest sets the status of the user's access request.
+func (r *RepoAccessRequestResource) handleRequest(data *RepoAccessRequestResourceModel) error {
+	reqBody := fmt.Sprintf(`{"user": %s, "status": %s}`, jsonStringOrNull(data.User), jsonStringOrNull(data.Status))
+	if data.Status.ValueString() == "rejected" && !data.RejectionReason.IsNull() {
+		reqBody = fmt.Sprintf(`{"user": %s, "status": "rejected", "rejectionReason": %s}`, jsonStringOrNull(data.User), jsonStringOrNull(data.RejectionReason))
+	}
+
+	return r.postAccessRequest(data, "handle", reqBody)
+}
+
+// postAccessRequest calls an access request endpoint such as "handle" or
+// "grant". It returns errAccessRequestNotFound when the user has no request.
+func (r *RepoAccessRequestResource) postAccessRequest(data *RepoAccessRequestResourceModel, action, reqBody string) error {
+	url := fmt.Sprintf("https://huggingface.co/api/%s/%s/user-access-request/%s", repoURLPrefix(data.RepoType.ValueString()), data.RepoID.ValueString(), action)
+	log.Printf("[DEBUG] Access Request %s Request Body: %s", action, reqBody)
+
+	httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return errAccessRequestNotFound
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// fetchAccessRequestStatus returns the status of a user's access request to
+// a gated repository.
+func fetchAccessRequestStatus(ctx context.Context, client *http.Client, repoType, repoID, user string) (string, error) {
+	for _, status := range accessRequestStatuses {
+		url := fmt.Sprintf("https://huggingface.co/api/%s/%s/user-access-request/%s", repoURLPrefix(repoType), repoID, status)
+
+		pages := newPaginator(client, url, -1)
+		for pages.HasNext() {
+			items, err := pages.Next(ctx)
+			if errors.Is(err, errPageNotFound) {
+				return "", errAccessRequestNotFound
+			}
+			if err != nil {
+				return "", err
+			}
+
+			for _, item := range items {
+				var request struct {
+					User struct {
+						User string `json:"user"`
+					} `json:"user"`
+				}
+				if err := json.Unmarshal(item, &request); err != nil {
+					return "", err
+				}
+
+				if request.User.User == user {
+					return status, nil
+				}
+			}
+		}
+	}
+
+	return "", errAccessRequestNotFound
+}
+
+func (r *RepoAccessRequestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.TrimSpace(req.ID)
+
+	var repoType, repoID, user string
+	err := fmt.Errorf("missing user")
+
+	if idx := strings.LastIndex(id, "/"); idx != -1 && idx < len(id)-1 {
+		user = id[idx+1:]
+		repoType, repoID, err = parseTypedRepoImportID(id[:idx])
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected type/owner/name/user, got: %s (%s)", req.ID, err),
+		)
+		return
+	}
+
+	// The status is filled in by Read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%s/%s/%s", repoType, repoID, user))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), user)...)
+}
+
+func NewRepoAccessRequestResource() resource.Resource {
+	return &RepoAccessRequestResource{}
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoBranchResource{}
+	_ resource.ResourceWithConfigure   = &RepoBranchResource{}
+	_ resource.ResourceWithImportState = &RepoBranchResource{}
+)
+
+// RepoBranchResource defines the resource implementation.
+type RepoBranchResource struct {
+	client *http.Client
+}
+
+// RepoBranchResourceModel describes the resource data model.
+type RepoBranchResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	RepoType types.String `tfsdk:"repo_type"`
+	RepoID   types.String `tfsdk:"repo_id"`
+	Branch   types.String `tfsdk:"branch"`
+	Revision types.String `tfsdk:"revision"`
+	Commit   types.String `tfsdk:"commit"`
+}
+
+func (r *RepoBranchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_branch"
+}
+
+func (r *RepoBranchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a branch in a model, dataset or Space repository. Changing any argument forces a new branch to be created.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the branch, `type/owner/name@branch`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the repository: `model`, `dataset` or `space`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "ID (`owner/name`) of the repository.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "Name of the branch.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "Branch, tag or commit to create the branch from. Defaults to the head of `main`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						recordedRefChanged,
+						"Changing it forces a new branch to be created, unless it was not recorded because the branch was imported.",
+						"Changing it forces a new branch to be created, unless it was not recorded because the branch was imported.",
+					),
+				},
+			},
+			"commit": schema.StringAttribute{
+				MarkdownDescription: "Commit the branch points to.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *RepoBranchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RepoBranchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := repoRefURL(data.RepoType.ValueString(), data.RepoID.ValueString(), "branch", data.Branch.ValueString())
+
+	reqBody := "{}"
+	if !data.Revision.IsNull() {
+		reqBody = fmt.Sprintf(`{"startingPoint": %s}`, jsonStringOrNull(data.Revision))
+	}
+
+	err := postRepoRef(r.client, url, reqBody)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create branch, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(repoRefID(data.RepoType.ValueString(), data.RepoID.ValueString(), data.Branch.ValueString()))
+
+	err = r.readBranch(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read created branch, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoBranchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.readBranch(data)
+	if errors.Is(err, errRefNotFound) {
+		log.Printf("[DEBUG] Branch %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read branch, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoBranchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// The only in-place update records the creation arguments of an imported
+	// branch, so there is nothing to send to the API.
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state RepoBranchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Commit = state.Commit
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoBranchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepoBranchResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := repoRefURL(data.RepoType.ValueString(), data.RepoID.ValueString(), "branch", data.Branch.ValueString())
+
+	err := deleteRepoRef(r.client, url)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete branch, got error: %s", err))
+		return
+	}
+}
+
+// readBranch refreshes the commit the branch points to.
+func (r *RepoBranchResource) readBranch(data *RepoBranchResourceModel) error {
+	refs, err := fetchRepoRefs(r.client, data.RepoType.ValueString(), data.RepoID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	branch, err := findRepoRef(refs.Branches, data.Branch.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.Commit = types.StringValue(branch.TargetCommit)
+
+	return nil
+}
+
+func (r *RepoBranchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, branch, err := parseRepoRefID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected type/owner/name@branch, got: %s (%s)", req.ID, err),
+		)
+		return
+	}
+
+	// The commit is filled in by Read. The revision the branch was created
+	// from is not recorded by the Hub.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoRefID(repoType, repoID, branch))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch"), branch)...)
+	resp.Diagnostics.Append(setPrivateFlag(ctx, resp.Private, importedRefKey, true)...)
+}
+
+func NewRepoBranchResource() resource.Resource {
+	return &RepoBranchResource{}
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// errRefNotFound is returned when a branch or tag does not exist (anymore).
+var errRefNotFound = errors.New("ref not found")
+
+// repoRef is a branch or tag of a repository.
+type repoRef struct {
+	Name         string `json:"name"`
+	Ref          string `json:"ref"`
+	TargetCommit string `json:"targetCommit"`
+}
+
+// repoRefs lists the branches and tags of a repository.
+type repoRefs struct {
+	Branches []repoRef `json:"branches"`
+	Tags     []repoRef `json:"tags"`
+}
+
+// repoRefURL returns the API URL of a ref endpoint ("branch" or "tag") of a
+// repository.
+func repoRefURL(repoType, repoID, kind, ref string) string {
+	return fmt.Sprintf("https://huggingface.co/api/%s/%s/%s/%s", repoURLPrefix(repoType), repoID, kind, url.PathEscape(ref))
+}
+
+// fetchRepoRefs returns the branches and tags of a repository. It returns
+// errRefNotFound when the repository does not exist.
+func fetchRepoRefs(client *http.Client, repoType, repoID string) (*repoRefs, error) {
+	url := fmt.Sprintf("https://huggingface.co/api/%s/%s/refs", repoURLPrefix(repoType), repoID)
+	log.Printf("[DEBUG] Requesting URL: %s", url)
+
+	httpResp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, errRefNotFound
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var refs repoRefs
+	err = json.NewDecoder(httpResp.Body).Decode(&refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &refs, nil
+}
+
+// findRepoRef returns the ref with the given name, or errRefNotFound.
+func findRepoRef(refs []repoRef, name string) (repoRef, error) {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return ref, nil
+		}
+	}
+
+	return repoRef{}, errRefNotFound
+}
+
+// postRepoRef creates a branch or tag with the given request body.
+func postRepoRef(client *http.Client, url, reqBody string) error {
+	log.Printf("[DEBUG] Create Ref Request Body: %s", reqBody)
+
+	httpResp, err := client.Post(url, "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// deleteRepoRef deletes a branch or tag. Deleting a ref that no longer exists
+// is not an error.
+func deleteRepoRef(client *http.Client, url string) error {
+	httpReq, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// importedRefKey is the private state key marking a branch or tag as
+// imported rather than created by Terraform.
+const importedRefKey = "imported"
+
+// recordedRefChanged requires replacing a branch or tag when an argument it
+// was created with changes. The Hub does not report these arguments, so they
+// are null after import; setting them on an imported ref only records them in
+// state.
+func recordedRefChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	imported, diags := privateFlag(ctx, req.Private, importedRefKey)
+	resp.Diagnostics.Append(diags...)
+
+	resp.RequiresReplace = !imported || !req.StateValue.IsNull()
+}
+
+// repoRefID returns the ID of a branch or tag resource, type/owner/name@ref.
+func repoRefID(repoType, repoID, ref string) string {
+	return fmt.Sprintf("%s/%s@%s", repoType, repoID, ref)
+}
+
+// parseRepoRefID splits a type/owner/name@ref ID.
+func parseRepoRefID(id string) (string, string, string, error) {
+	repo, ref, found := strings.Cut(strings.TrimSpace(id), "@")
+	if !found || ref == "" {
+		return "", "", "", fmt.Errorf("missing @ref")
+	}
+
+	repoType, repoID, err := parseTypedRepoImportID(repo)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return repoType, repoID, ref, nil
+}
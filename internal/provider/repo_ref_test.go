@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseRepoRefID(t *testing.T) {
+	tests := []struct {
+		id           string
+		wantRepoType string
+		wantRepoID   string
+		wantRef      string
+		wantErr      bool
+	}{
+		{id: "model/owner/name@v1.0", wantRepoType: "model", wantRepoID: "owner/name", wantRef: "v1.0"},
+		{id: " space/owner/name@staging ", wantRepoType: "space", wantRepoID: "owner/name", wantRef: "staging"},
+		{id: "dataset/owner/name@feature/x", wantRepoType: "dataset", wantRepoID: "owner/name", wantRef: "feature/x"},
+		{id: "model/owner/name@v1@rc", wantRepoType: "model", wantRepoID: "owner/name", wantRef: "v1@rc"},
+		{id: "https://huggingface.co/datasets/owner/name@main", wantRepoType: "dataset", wantRepoID: "owner/name", wantRef: "main"},
+		{id: "model/owner/name", wantErr: true},
+		{id: "model/owner/name@", wantErr: true},
+		{id: "owner/name@main", wantErr: true},
+		{id: "model/owner@main", wantErr: true},
+		{id: "@main", wantErr: true},
+		{id: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			repoType, repoID, ref, err := parseRepoRefID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRepoRefID(%q) error = %v, want error: %t", tt.id, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if repoType != tt.wantRepoType || repoID != tt.wantRepoID || ref != tt.wantRef {
+				t.Errorf("parseRepoRefID(%q) = %q, %q, %q, want %q, %q, %q", tt.id, repoType, repoID, ref, tt.wantRepoType, tt.wantRepoID, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestRecordedRefChanged(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       types.String
+		plan        types.String
+		wantReplace bool
+	}{
+		{name: "set after create", state: types.StringNull(), plan: types.StringValue("main"), wantReplace: true},
+		{name: "changed", state: types.StringValue("main"), plan: types.StringValue("dev"), wantReplace: true},
+		{name: "removed", state: types.StringValue("main"), plan: types.StringNull(), wantReplace: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{StateValue: tt.state, PlanValue: tt.plan}
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+
+			recordedRefChanged(context.Background(), req, resp)
+
+			if resp.RequiresReplace != tt.wantReplace {
+				t.Errorf("RequiresReplace = %t, want %t", resp.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+}
@@ -0,0 +1,632 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoResource{}
+	_ resource.ResourceWithConfigure   = &RepoResource{}
+	_ resource.ResourceWithImportState = &RepoResource{}
+	_ resource.ResourceWithModifyPlan  = &RepoResource{}
+)
+
+// RepoResource defines the resource implementation. The same implementation
+// manages models and datasets, which only differ in their repository type,
+// and generic repositories whose type is configured in repo_type.
+type RepoResource struct {
+	client   *http.Client
+	repoType string
+}
+
+// RepoResourceModel describes the resource data model. RepoType is only
+// part of the schema of generic repositories, see getData.
+type RepoResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	RepoType  types.String `tfsdk:"-"`
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+	FullName  types.String `tfsdk:"full_name"`
+	Private   types.Bool   `tfsdk:"private"`
+	Gated     types.String `tfsdk:"gated"`
+	License   types.String `tfsdk:"license"`
+	Tags      types.List   `tfsdk:"tags"`
+	Card      types.String `tfsdk:"card"`
+
+	GatedPrompt types.String `tfsdk:"gated_prompt"`
+	GatedFields types.Map    `tfsdk:"gated_fields"`
+}
+
+func (r *RepoResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	if r.repoType == "" {
+		resp.TypeName = req.ProviderTypeName + "_repo"
+		return
+	}
+
+	resp.TypeName = req.ProviderTypeName + "_" + r.repoType
+}
+
+// noun returns how the resource refers to the repository it manages.
+func (r *RepoResource) noun() string {
+	if r.repoType == "" {
+		return "repository"
+	}
+
+	return r.repoType
+}
+
+// typeOf returns the type of the repository, which is fixed for models and
+// datasets and configured for generic repositories.
+func (r *RepoResource) typeOf(data *RepoResourceModel) string {
+	if r.repoType != "" {
+		return r.repoType
+	}
+
+	return data.RepoType.ValueString()
+}
+
+// repoData is a plan, state or configuration of the resource.
+type repoData interface {
+	Get(ctx context.Context, target interface{}) diag.Diagnostics
+}
+
+// getData reads data from a plan, state or configuration. Only generic
+// repositories have a repo_type attribute, as the type of models and
+// datasets is fixed, so it is read separately from the other attributes.
+func (r *RepoResource) getData(ctx context.Context, source repoData, data *RepoResourceModel) diag.Diagnostics {
+	var object types.Object
+
+	diags := source.Get(ctx, &object)
+	if diags.HasError() {
+		return diags
+	}
+
+	attributes := object.Attributes()
+	attributeTypes := object.AttributeTypes(ctx)
+
+	repoType := types.StringValue(r.repoType)
+	if r.repoType == "" {
+		repoType, _ = attributes["repo_type"].(types.String)
+		delete(attributes, "repo_type")
+		delete(attributeTypes, "repo_type")
+	}
+
+	object, d := types.ObjectValue(attributeTypes, attributes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(object.As(ctx, data, basetypes.ObjectAsOptions{})...)
+	data.RepoType = repoType
+
+	return diags
+}
+
+// setState saves data to the state, including repo_type for generic
+// repositories.
+func (r *RepoResource) setState(ctx context.Context, state *tfsdk.State, data *RepoResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attributeTypes := make(map[string]attr.Type)
+	for name, attributeType := range state.Schema.Type().(types.ObjectType).AttrTypes {
+		if name != "repo_type" {
+			attributeTypes[name] = attributeType
+		}
+	}
+
+	object, d := types.ObjectValueFrom(ctx, attributeTypes, data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if r.repoType == "" {
+		attributes := object.Attributes()
+		attributes["repo_type"] = data.RepoType
+		attributeTypes["repo_type"] = types.StringType
+
+		object, d = types.ObjectValue(attributeTypes, attributes)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags.Append(state.Set(ctx, object)...)
+
+	return diags
+}
+
+func (r *RepoResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+
+	description := fmt.Sprintf("Manages a %[1]s repository on the Hugging Face Hub. Files other than the %[1]s card are not managed.", r.noun())
+	if r.repoType == "" {
+		description = "Manages a model, dataset or Space repository on the Hugging Face Hub. Files other than the repository card are not managed."
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Canonical ID of the %s, `namespace/name`.", r.noun()),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Short name of the %[1]s, without the namespace. Changing it renames the %[1]s.", r.noun()),
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("User or organization that owns the %[1]s. Defaults to the user of the token. Changing it moves the %[1]s.", r.noun()),
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"full_name": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Full name of the %s, `namespace/name`.", r.noun()),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"gated": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Whether users must request access to the %s: `false`, `auto` (requests are accepted automatically) or `manual`.", r.noun()),
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(gatedModes...),
+				},
+			},
+			"gated_prompt": schema.StringAttribute{
+				MarkdownDescription: "Text shown to users when they request access to a gated repository. Left unmanaged when not set.",
+				Optional:            true,
+			},
+			"gated_fields": schema.MapAttribute{
+				MarkdownDescription: "Fields users fill in when they request access to a gated repository, mapping each label to its type: `" + strings.Join(gatedFieldTypes, "`, `") + "`. Left unmanaged when not set.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(gatedFieldTypes...)),
+				},
+			},
+			"license": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("License of the %s in the card metadata, e.g. `apache-2.0` or `mit`. Left unmanaged when not set.", r.noun()),
+				Optional:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: fmt.Sprintf("Tags of the %s in the card metadata. Left unmanaged when not set.", r.noun()),
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"card": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Markdown content of the %s card, below the card metadata. Left unmanaged when not set.", r.noun()),
+				Optional:            true,
+			},
+		},
+	}
+
+	// The type of models and datasets is fixed, generic repositories are
+	// replaced when it changes.
+	if r.repoType == "" {
+		resp.Schema.Attributes["repo_type"] = schema.StringAttribute{
+			MarkdownDescription: "Type of the repository: `model`, `dataset` or `space`. Spaces are created with the `static` SDK; use `huggingface-spaces_space` to manage their runtime. Changing it forces a new repository to be created.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.OneOf(repoTypes...),
+			},
+		}
+	}
+}
+
+func (r *RepoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on creation or when destroying.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, state RepoResourceModel
+
+	resp.Diagnostics.Append(r.getData(ctx, req.Config, &config)...)
+	resp.Diagnostics.Append(r.getData(ctx, req.State, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Renaming or moving the repository changes its ID.
+	moved := !config.Namespace.IsNull() && !config.Namespace.IsUnknown() && !config.Namespace.Equal(state.Namespace)
+	if moved || config.Name.IsUnknown() || !config.Name.Equal(state.Name) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("full_name"), types.StringUnknown())...)
+	}
+}
+
+func (r *RepoResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RepoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	data := &RepoResourceModel{}
+
+	resp.Diagnostics.Append(r.getData(ctx, req.Plan, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Hub requires an SDK for Spaces.
+	var options map[string]interface{}
+	if r.typeOf(data) == "space" {
+		options = map[string]interface{}{"sdk": "static"}
+	}
+
+	repoID, err := createRepo(r.client, r.typeOf(data), data.Name.ValueString(), data.Namespace, data.Private.ValueBool(), options)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create %s, got error: %s", r.noun(), err))
+		return
+	}
+
+	data.ID = types.StringValue(repoID)
+
+	if !data.Gated.IsUnknown() && data.Gated.ValueString() != "false" {
+		err := updateRepoSettings(r.client, r.typeOf(data), repoID, map[string]interface{}{
+			"gated": gatedSetting(data.Gated),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update %s gating, got error: %s", r.noun(), err))
+			return
+		}
+	}
+
+	if !data.License.IsNull() || !data.Tags.IsNull() || !data.Card.IsNull() || !data.GatedPrompt.IsNull() || !data.GatedFields.IsNull() {
+		err := r.updateCard(ctx, data)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update %s card, got error: %s", r.noun(), err))
+			return
+		}
+	}
+
+	// Fill in the computed attributes that were not configured.
+	planned := *data
+
+	err = r.readRepo(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read created %s, got error: %s", r.noun(), err))
+		return
+	}
+
+	if !planned.Private.IsUnknown() {
+		data.Private = planned.Private
+	}
+	if !planned.Gated.IsUnknown() {
+		data.Gated = planned.Gated
+	}
+	data.License = planned.License
+	data.Tags = planned.Tags
+	data.Card = planned.Card
+	data.GatedPrompt = planned.GatedPrompt
+	data.GatedFields = planned.GatedFields
+
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, data)...)
+}
+
+func (r *RepoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	data := &RepoResourceModel{}
+
+	resp.Diagnostics.Append(r.getData(ctx, req.State, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.readRepo(data)
+	if errors.Is(err, errRepoNotFound) {
+		log.Printf("[DEBUG] %s %s no longer exists, removing it from state", r.typeOf(data), data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", r.noun(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, data)...)
+}
+
+func (r *RepoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	data := &RepoResourceModel{}
+
+	resp.Diagnostics.Append(r.getData(ctx, req.Plan, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state RepoResourceModel
+	resp.Diagnostics.Append(r.getData(ctx, req.State, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if the repository needs to be renamed or moved to another namespace
+	if state.Namespace.IsNull() {
+		stateNamespace, _ := splitRepoID(state.ID.ValueString())
+		state.Namespace = types.StringValue(stateNamespace)
+	}
+
+	namespace := state.Namespace
+	if !data.Namespace.IsUnknown() && !data.Namespace.IsNull() {
+		namespace = data.Namespace
+	}
+
+	if state.Name.ValueString() != data.Name.ValueString() || !state.Namespace.Equal(namespace) {
+		toRepo := fmt.Sprintf("%s/%s", namespace.ValueString(), data.Name.ValueString())
+
+		err := moveRepo(r.client, r.typeOf(data), state.ID.ValueString(), toRepo)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to rename %s, got error: %s", r.noun(), err))
+			return
+		}
+
+		state.ID = types.StringValue(toRepo)
+		state.Name = data.Name
+		state.Namespace = namespace
+		state.FullName = state.ID
+	}
+
+	// Check if the visibility or gating needs to be updated
+	settings := map[string]interface{}{}
+	if !data.Private.IsUnknown() && !state.Private.Equal(data.Private) {
+		settings["private"] = data.Private.ValueBool()
+	}
+	if !data.Gated.IsUnknown() && !state.Gated.Equal(data.Gated) {
+		settings["gated"] = gatedSetting(data.Gated)
+	}
+
+	if len(settings) > 0 {
+		err := updateRepoSettings(r.client, r.typeOf(data), state.ID.ValueString(), settings)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update %s settings, got error: %s", r.noun(), err))
+			return
+		}
+
+		if !data.Private.IsUnknown() {
+			state.Private = data.Private
+		}
+		if !data.Gated.IsUnknown() {
+			state.Gated = data.Gated
+		}
+	}
+
+	// Update the card
+	cardChanged := !state.License.Equal(data.License) || !state.Tags.Equal(data.Tags) || !state.Card.Equal(data.Card)
+	gatingChanged := !state.GatedPrompt.Equal(data.GatedPrompt) || !state.GatedFields.Equal(data.GatedFields)
+
+	if cardChanged || gatingChanged {
+		data.ID = state.ID
+
+		err := r.updateCard(ctx, data)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update %s card, got error: %s", r.noun(), err))
+			return
+		}
+	}
+
+	state.License = data.License
+	state.Tags = data.Tags
+	state.Card = data.Card
+	state.GatedPrompt = data.GatedPrompt
+	state.GatedFields = data.GatedFields
+
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, &state)...)
+}
+
+func (r *RepoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	data := &RepoResourceModel{}
+
+	resp.Diagnostics.Append(r.getData(ctx, req.State, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteRepo(r.client, r.typeOf(data), data.Name.ValueString(), data.Namespace)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete %s, got error: %s", r.noun(), err))
+		return
+	}
+}
+
+// updateCard writes the managed card metadata and content of the repository.
+func (r *RepoResource) updateCard(ctx context.Context, data *RepoResourceModel) error {
+	values := map[string]string{}
+
+	if !data.License.IsNull() {
+		values["license"] = cardString(data.License.ValueString())
+	}
+
+	if !data.Tags.IsNull() {
+		var tags []string
+		if diags := data.Tags.ElementsAs(ctx, &tags, false); diags.HasError() {
+			return fmt.Errorf("unable to read tags")
+		}
+		values["tags"] = cardList(tags)
+	}
+
+	if !data.GatedPrompt.IsNull() {
+		values["extra_gated_prompt"] = cardString(data.GatedPrompt.ValueString())
+	}
+
+	if !data.GatedFields.IsNull() {
+		var fields map[string]string
+		if diags := data.GatedFields.ElementsAs(ctx, &fields, false); diags.HasError() {
+			return fmt.Errorf("unable to read gated fields")
+		}
+		values["extra_gated_fields"] = cardMap(fields)
+	}
+
+	var body *string
+	if !data.Card.IsNull() {
+		card := data.Card.ValueString()
+		body = &card
+	}
+
+	return updateRepoCard(r.client, r.typeOf(data), data.ID.ValueString(), values, body)
+}
+
+// readRepo refreshes data from the Hub. The license, tags, card and access
+// request form are only refreshed when they are managed by the resource.
+func (r *RepoResource) readRepo(data *RepoResourceModel) error {
+	repo, err := fetchRepo(r.client, r.typeOf(data), data.ID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Repo JSON Response: %+v", repo)
+
+	data.RepoType = types.StringValue(r.typeOf(data))
+
+	if id, ok := repo["id"].(string); ok {
+		namespace, name := splitRepoID(id)
+		data.ID = types.StringValue(id)
+		data.FullName = types.StringValue(id)
+		data.Namespace = types.StringValue(namespace)
+		data.Name = types.StringValue(name)
+	}
+
+	if private, ok := repo["private"].(bool); ok {
+		data.Private = types.BoolValue(private)
+	}
+
+	data.Gated = repoGated(repo)
+
+	cardData, _ := repo["cardData"].(map[string]interface{})
+
+	if !data.License.IsNull() {
+		data.License = cardDataString(cardData, "license", data.License)
+	}
+
+	if !data.Tags.IsNull() {
+		data.Tags = optionalStringList(cardData["tags"])
+	}
+
+	if !data.GatedPrompt.IsNull() {
+		data.GatedPrompt = cardDataString(cardData, "extra_gated_prompt", data.GatedPrompt)
+	}
+
+	if !data.GatedFields.IsNull() {
+		data.GatedFields = cardDataStringMap(cardData, "extra_gated_fields")
+	}
+
+	if !data.Card.IsNull() {
+		readme, err := fetchRepoReadme(r.client, r.typeOf(data), data.ID.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to read README.md: %w", err)
+		}
+		data.Card = types.StringValue(cardBody(readme))
+	}
+
+	return nil
+}
+
+func (r *RepoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType := r.repoType
+	var repoID string
+	var err error
+
+	if repoType == "" {
+		repoType, repoID, err = parseTypedRepoImportID(req.ID)
+	} else {
+		repoID, err = parseRepoImportID(repoType, req.ID)
+	}
+	if err != nil {
+		expected := fmt.Sprintf("owner/name or %s", repoWebURL(repoType, "owner/name"))
+		if r.repoType == "" {
+			expected = "type/owner/name or the URL of the repository"
+		}
+
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected %s, got: %s (%s)", expected, req.ID, err),
+		)
+		return
+	}
+
+	namespace, name := splitRepoID(repoID)
+
+	// The remaining attributes are filled in by Read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoID)...)
+	if r.repoType == "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("full_name"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func NewModelResource() resource.Resource {
+	return &RepoResource{repoType: "model"}
+}
+
+func NewDatasetResource() resource.Resource {
+	return &RepoResource{repoType: "dataset"}
+}
+
+func NewRepoResource() resource.Resource {
+	return &RepoResource{}
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepoTagResource{}
+	_ resource.ResourceWithConfigure   = &RepoTagResource{}
+	_ resource.ResourceWithImportState = &RepoTagResource{}
+)
+
+// RepoTagResource defines the resource implementation.
+type RepoTagResource struct {
+	client *http.Client
+}
+
+// RepoTagResourceModel describes the resource data model.
+type RepoTagResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	RepoType types.String `tfsdk:"repo_type"`
+	RepoID   types.String `tfsdk:"repo_id"`
+	Tag      types.String `tfsdk:"tag"`
+	Revision types.String `tfsdk:"revision"`
+	Message  types.String `tfsdk:"message"`
+	Commit   types.String `tfsdk:"commit"`
+}
+
+func (r *RepoTagResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_tag"
+}
+
+func (r *RepoTagResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tags a commit of a model, dataset or Space repository. Changing any argument forces the tag to be recreated.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the tag, `type/owner/name@tag`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the repository: `model`, `dataset` or `space`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "ID (`owner/name`) of the repository.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "Name of the tag, e.g. `v1.0`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "Branch or commit to tag. Defaults to the head of `main`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						recordedRefChanged,
+						"Changing it forces a new tag to be created, unless it was not recorded because the tag was imported.",
+						"Changing it forces a new tag to be created, unless it was not recorded because the tag was imported.",
+					),
+				},
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "Message of the tag.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						recordedRefChanged,
+						"Changing it forces a new tag to be created, unless it was not recorded because the tag was imported.",
+						"Changing it forces a new tag to be created, unless it was not recorded because the tag was imported.",
+					),
+				},
+			},
+			"commit": schema.StringAttribute{
+				MarkdownDescription: "Commit the tag points to.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *RepoTagResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RepoTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revision := "main"
+	if !data.Revision.IsNull() {
+		revision = data.Revision.ValueString()
+	}
+
+	url := repoRefURL(data.RepoType.ValueString(), data.RepoID.ValueString(), "tag", revision)
+
+	reqBody := fmt.Sprintf(`{"tag": %s, "message": %s}`, jsonStringOrNull(data.Tag), jsonStringOrNull(data.Message))
+
+	err := postRepoRef(r.client, url, reqBody)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create tag, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(repoRefID(data.RepoType.ValueString(), data.RepoID.ValueString(), data.Tag.ValueString()))
+
+	err = r.readTag(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read created tag, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.readTag(data)
+	if errors.Is(err, errRefNotFound) {
+		log.Printf("[DEBUG] Tag %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tag, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// The only in-place update records the creation arguments of an imported
+	// tag, so there is nothing to send to the API.
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state RepoTagResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Commit = state.Commit
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepoTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepoTagResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := repoRefURL(data.RepoType.ValueString(), data.RepoID.ValueString(), "tag", data.Tag.ValueString())
+
+	err := deleteRepoRef(r.client, url)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete tag, got error: %s", err))
+		return
+	}
+}
+
+// readTag refreshes the commit the tag points to.
+func (r *RepoTagResource) readTag(data *RepoTagResourceModel) error {
+	refs, err := fetchRepoRefs(r.client, data.RepoType.ValueString(), data.RepoID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	tag, err := findRepoRef(refs.Tags, data.Tag.ValueString())
+	if err != nil {
+		return err
+	}
+
+	data.Commit = types.StringValue(tag.TargetCommit)
+
+	return nil
+}
+
+func (r *RepoTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, tag, err := parseRepoRefID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected type/owner/name@tag, got: %s (%s)", req.ID, err),
+		)
+		return
+	}
+
+	// The commit is filled in by Read. The revision and message of the tag
+	// are not read back from the Hub.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoRefID(repoType, repoID, tag))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), tag)...)
+	resp.Diagnostics.Append(setPrivateFlag(ctx, resp.Private, importedRefKey, true)...)
+}
+
+func NewRepoTagResource() resource.Resource {
+	return &RepoTagResource{}
+}
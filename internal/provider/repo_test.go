@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseRepoImportID(t *testing.T) {
+	tests := []struct {
+		repoType string
+		importID string
+		want     string
+		wantErr  bool
+	}{
+		{repoType: "model", importID: "owner/name", want: "owner/name"},
+		{repoType: "model", importID: " owner/name ", want: "owner/name"},
+		{repoType: "model", importID: "https://huggingface.co/owner/name", want: "owner/name"},
+		{repoType: "model", importID: "https://www.huggingface.co/owner/name/", want: "owner/name"},
+		{repoType: "model", importID: "http://huggingface.co/owner/name/tree/main", want: "owner/name"},
+		{repoType: "dataset", importID: "owner/name", want: "owner/name"},
+		{repoType: "dataset", importID: "https://huggingface.co/datasets/owner/name", want: "owner/name"},
+		{repoType: "dataset", importID: "huggingface.co/datasets/owner/name/blob/main/README.md", want: "owner/name"},
+		{repoType: "space", importID: "https://huggingface.co/spaces/owner/name", want: "owner/name"},
+		{repoType: "dataset", importID: "https://huggingface.co/owner/name", wantErr: true},
+		{repoType: "space", importID: "https://huggingface.co/datasets/owner/name", wantErr: true},
+		{repoType: "model", importID: "https://huggingface.co/owner", wantErr: true},
+		{repoType: "dataset", importID: "https://huggingface.co/datasets/owner", wantErr: true},
+		{repoType: "model", importID: "owner", wantErr: true},
+		{repoType: "model", importID: "owner/", wantErr: true},
+		{repoType: "model", importID: "/name", wantErr: true},
+		{repoType: "model", importID: "owner/name/extra", wantErr: true},
+		{repoType: "model", importID: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoType+" "+tt.importID, func(t *testing.T) {
+			got, err := parseRepoImportID(tt.repoType, tt.importID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRepoImportID(%q, %q) error = %v, want error: %t", tt.repoType, tt.importID, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseRepoImportID(%q, %q) = %q, want %q", tt.repoType, tt.importID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTypedRepoImportID(t *testing.T) {
+	tests := []struct {
+		importID     string
+		wantRepoType string
+		wantRepoID   string
+		wantErr      bool
+	}{
+		{importID: "model/owner/name", wantRepoType: "model", wantRepoID: "owner/name"},
+		{importID: "dataset/owner/name", wantRepoType: "dataset", wantRepoID: "owner/name"},
+		{importID: " space/owner/name ", wantRepoType: "space", wantRepoID: "owner/name"},
+		{importID: "https://huggingface.co/owner/name", wantRepoType: "model", wantRepoID: "owner/name"},
+		{importID: "https://huggingface.co/datasets/owner/name", wantRepoType: "dataset", wantRepoID: "owner/name"},
+		{importID: "https://huggingface.co/spaces/owner/name/tree/main", wantRepoType: "space", wantRepoID: "owner/name"},
+		{importID: "owner/name", wantErr: true},
+		{importID: "models/owner/name", wantErr: true},
+		{importID: "dataset/owner", wantErr: true},
+		{importID: "dataset/owner/name/extra", wantErr: true},
+		{importID: "https://huggingface.co/datasets/owner", wantErr: true},
+		{importID: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importID, func(t *testing.T) {
+			repoType, repoID, err := parseTypedRepoImportID(tt.importID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTypedRepoImportID(%q) error = %v, want error: %t", tt.importID, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if repoType != tt.wantRepoType || repoID != tt.wantRepoID {
+				t.Errorf("parseTypedRepoImportID(%q) = %q, %q, want %q, %q", tt.importID, repoType, repoID, tt.wantRepoType, tt.wantRepoID)
+			}
+		})
+	}
+}
+
+func TestRepoResourceStateRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		resource     resource.Resource
+		repoType     types.String
+		wantRepoType types.String
+	}{
+		{name: "model", resource: NewModelResource(), repoType: types.StringNull(), wantRepoType: types.StringValue("model")},
+		{name: "dataset", resource: NewDatasetResource(), repoType: types.StringNull(), wantRepoType: types.StringValue("dataset")},
+		{name: "generic", resource: NewRepoResource(), repoType: types.StringValue("space"), wantRepoType: types.StringValue("space")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := tt.resource.(*RepoResource)
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			data := &RepoResourceModel{
+				ID:          types.StringValue("owner/name"),
+				RepoType:    tt.repoType,
+				Name:        types.StringValue("name"),
+				Namespace:   types.StringValue("owner"),
+				FullName:    types.StringValue("owner/name"),
+				Private:     types.BoolValue(true),
+				Gated:       types.StringValue("auto"),
+				License:     types.StringValue("mit"),
+				Tags:        types.ListValueMust(types.StringType, []attr.Value{types.StringValue("nlp")}),
+				Card:        types.StringNull(),
+				GatedPrompt: types.StringValue("Tell us about you"),
+				GatedFields: types.MapValueMust(types.StringType, map[string]attr.Value{"Company": types.StringValue("text")}),
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := r.setState(ctx, &state, data); diags.HasError() {
+				t.Fatalf("setState() error: %v", diags)
+			}
+
+			// Only generic repositories have a repo_type attribute.
+			var repoType types.String
+			diags := state.GetAttribute(ctx, path.Root("repo_type"), &repoType)
+			wantAttribute := r.repoType == ""
+			if gotAttribute := !diags.HasError(); gotAttribute != wantAttribute {
+				t.Errorf("state has repo_type = %t, want %t", gotAttribute, wantAttribute)
+			}
+
+			got := &RepoResourceModel{}
+			if diags := r.getData(ctx, state, got); diags.HasError() {
+				t.Fatalf("getData() error: %v", diags)
+			}
+
+			want := *data
+			want.RepoType = tt.wantRepoType
+			if !reflect.DeepEqual(*got, want) {
+				t.Errorf("getData() = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
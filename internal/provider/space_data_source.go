@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -24,6 +26,8 @@ type SpaceDataSource struct {
 type SpaceDataSourceModel struct {
 	ID           types.String `tfsdk:"id"`
 	Name         types.String `tfsdk:"name"`
+	Namespace    types.String `tfsdk:"namespace"`
+	FullName     types.String `tfsdk:"full_name"`
 	Author       types.String `tfsdk:"author"`
 	LastModified types.String `tfsdk:"last_modified"`
 	Likes        types.Int64  `tfsdk:"likes"`
@@ -32,6 +36,17 @@ type SpaceDataSourceModel struct {
 	Hardware     types.String `tfsdk:"hardware"`
 	Storage      types.String `tfsdk:"storage"`
 	SleepTime    types.Int64  `tfsdk:"sleep_time"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	SHA          types.String `tfsdk:"sha"`
+	Tags         types.List   `tfsdk:"tags"`
+	CardData     types.String `tfsdk:"card_data"`
+	Siblings     types.List   `tfsdk:"siblings"`
+	Models       types.List   `tfsdk:"models"`
+	Datasets     types.List   `tfsdk:"datasets"`
+	Disabled     types.Bool   `tfsdk:"disabled"`
+	Gated        types.String `tfsdk:"gated"`
+	Subdomain    types.String `tfsdk:"subdomain"`
+	Stage        types.String `tfsdk:"stage"`
 }
 
 func (d *SpaceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -42,10 +57,20 @@ func (d *SpaceDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required: true,
+				MarkdownDescription: "ID of the Space, `namespace/name`.",
+				Required:            true,
 			},
 			"name": schema.StringAttribute{
-				Computed: true,
+				MarkdownDescription: "Short name of the Space, without the namespace.",
+				Computed:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "User or organization that owns the Space.",
+				Computed:            true,
+			},
+			"full_name": schema.StringAttribute{
+				MarkdownDescription: "Canonical full name of the Space, `namespace/name`.",
+				Computed:            true,
 			},
 			"author": schema.StringAttribute{
 				Computed: true,
@@ -63,7 +88,8 @@ func (d *SpaceDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed: true,
 			},
 			"hardware": schema.StringAttribute{
-				Computed: true,
+				MarkdownDescription: "Hardware flavor requested for the Space, as in `huggingface-spaces_space`. While the hardware is being changed, the Space may still run on its previous hardware, which `huggingface-spaces_space_runtime` reports.",
+				Computed:            true,
 			},
 			"storage": schema.StringAttribute{
 				Computed: true,
@@ -71,6 +97,51 @@ func (d *SpaceDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 			"sleep_time": schema.Int64Attribute{
 				Computed: true,
 			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+			},
+			"sha": schema.StringAttribute{
+				MarkdownDescription: "Commit SHA of the main branch.",
+				Computed:            true,
+			},
+			"tags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"card_data": schema.StringAttribute{
+				MarkdownDescription: "Card metadata (the README front matter) as a JSON string. Use `jsondecode` to access it.",
+				Computed:            true,
+			},
+			"siblings": schema.ListAttribute{
+				MarkdownDescription: "Paths of the files in the Space repository.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"models": schema.ListAttribute{
+				MarkdownDescription: "IDs of the models the Space uses.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"datasets": schema.ListAttribute{
+				MarkdownDescription: "IDs of the datasets the Space uses.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"disabled": schema.BoolAttribute{
+				Computed: true,
+			},
+			"gated": schema.StringAttribute{
+				MarkdownDescription: "Access gating of the Space: `false`, `auto` or `manual`.",
+				Computed:            true,
+			},
+			"subdomain": schema.StringAttribute{
+				MarkdownDescription: "Subdomain of `hf.space` the Space is served on.",
+				Computed:            true,
+			},
+			"stage": schema.StringAttribute{
+				MarkdownDescription: "Runtime stage of the Space, e.g. `RUNNING`, `BUILDING` or `PAUSED`.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -133,73 +204,135 @@ func (d *SpaceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	log.Printf("[DEBUG] Space JSON Response: %+v", space)
 
 	if id, ok := space["id"].(string); ok {
-		data.Name = types.StringValue(id)
+		namespace, name := splitRepoID(id)
+		data.Name = types.StringValue(name)
+		data.Namespace = types.StringValue(namespace)
+		data.FullName = types.StringValue(id)
 	} else {
 		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'id' field is missing or not a string in the space JSON response")
 		return
 	}
 
-	if author, ok := space["author"].(string); ok {
-		data.Author = types.StringValue(author)
+	if private, ok := space["private"].(bool); ok {
+		data.Private = types.BoolValue(private)
 	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'author' field is missing or not a string in the space JSON response")
+		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'private' field is missing or not a boolean in the space JSON response")
 		return
 	}
 
-	if lastModified, ok := space["lastModified"].(string); ok {
-		data.LastModified = types.StringValue(lastModified)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'lastModified' field is missing or not a string in the space JSON response")
-		return
-	}
+	// The remaining fields are not returned for every Space, so missing ones
+	// are null rather than errors.
+	author, _ := space["author"].(string)
+	data.Author = optionalString(author)
 
+	lastModified, _ := space["lastModified"].(string)
+	data.LastModified = optionalString(lastModified)
+
+	data.Likes = types.Int64Null()
 	if likes, ok := space["likes"].(float64); ok {
 		data.Likes = types.Int64Value(int64(likes))
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'likes' field is missing or not a number in the space JSON response")
-		return
 	}
 
-	if private, ok := space["private"].(bool); ok {
-		data.Private = types.BoolValue(private)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'private' field is missing or not a boolean in the space JSON response")
-		return
+	sdk, _ := space["sdk"].(string)
+	data.SDK = optionalString(sdk)
+
+	// Hardware, storage and sleep time live in the runtime, and are null for
+	// free or never-configured Spaces.
+	runtime, _ := space["runtime"].(map[string]interface{})
+
+	data.Hardware = types.StringNull()
+	if hardware, ok := runtime["hardware"].(map[string]interface{}); ok {
+		if requested, ok := hardware["requested"].(string); ok {
+			data.Hardware = types.StringValue(requested)
+		} else if current, ok := hardware["current"].(string); ok {
+			data.Hardware = types.StringValue(current)
+		}
 	}
 
-	if sdk, ok := space["sdk"].(string); ok {
-		data.SDK = types.StringValue(sdk)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'sdk' field is missing or not a string in the space JSON response")
-		return
+	storage, _ := runtime["storage"].(string)
+	data.Storage = optionalString(storage)
+
+	data.SleepTime = types.Int64Null()
+	if sleepTime, ok := runtime["gcTimeout"].(float64); ok {
+		data.SleepTime = types.Int64Value(int64(sleepTime))
 	}
 
-	// Extract hardware, storage, and sleep time from the space JSON response
-	if hardware, ok := space["hardware"].(string); ok {
-		data.Hardware = types.StringValue(hardware)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'hardware' field is missing or not a string in the space JSON response")
-		return
+	createdAt, _ := space["createdAt"].(string)
+	data.CreatedAt = optionalString(createdAt)
+
+	sha, _ := space["sha"].(string)
+	data.SHA = optionalString(sha)
+
+	subdomain, _ := space["subdomain"].(string)
+	data.Subdomain = optionalString(subdomain)
+
+	data.Tags = optionalStringList(space["tags"])
+	data.Models = optionalStringList(space["models"])
+	data.Datasets = optionalStringList(space["datasets"])
+
+	data.Siblings = types.ListNull(types.StringType)
+	if siblings, ok := space["siblings"].([]interface{}); ok {
+		var files []interface{}
+		for _, sibling := range siblings {
+			if file, ok := sibling.(map[string]interface{}); ok {
+				files = append(files, file["rfilename"])
+			}
+		}
+		data.Siblings = optionalStringList(files)
 	}
 
-	if storage, ok := space["storage"].(string); ok {
-		data.Storage = types.StringValue(storage)
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'storage' field is missing or not a string in the space JSON response")
-		return
+	data.CardData = types.StringNull()
+	if cardData, ok := space["cardData"].(map[string]interface{}); ok {
+		encoded, err := json.Marshal(cardData)
+		if err != nil {
+			resp.Diagnostics.AddError("JSON Encode Error", fmt.Sprintf("Unable to encode card data, got error: %s", err))
+			return
+		}
+		data.CardData = types.StringValue(string(encoded))
 	}
 
-	if sleepTime, ok := space["sleepTime"].(float64); ok {
-		data.SleepTime = types.Int64Value(int64(sleepTime))
-	} else {
-		resp.Diagnostics.AddError("Missing or Invalid Field", "The 'sleepTime' field is missing or not a number in the space JSON response")
-		return
+	data.Disabled = types.BoolNull()
+	if disabled, ok := space["disabled"].(bool); ok {
+		data.Disabled = types.BoolValue(disabled)
 	}
 
+	// gated is either false or the gating mode.
+	switch gated := space["gated"].(type) {
+	case string:
+		data.Gated = types.StringValue(gated)
+	case bool:
+		data.Gated = types.StringValue(strconv.FormatBool(gated))
+	default:
+		data.Gated = types.StringNull()
+	}
+
+	stage, _ := runtime["stage"].(string)
+	data.Stage = optionalString(stage)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// optionalStringList converts a JSON array of strings to a list, or null if
+// the value is not an array. Elements that are not strings are skipped.
+func optionalStringList(value interface{}) types.List {
+	items, ok := value.([]interface{})
+	if !ok {
+		return types.ListNull(types.StringType)
+	}
+
+	elements := []attr.Value{}
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			elements = append(elements, types.StringValue(str))
+		}
+	}
+
+	list, _ := types.ListValue(types.StringType, elements)
+
+	return list
+}
+
 func NewSpaceDataSource() datasource.DataSource {
 	return &SpaceDataSource{}
 }
@@ -0,0 +1,367 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// customDomainListAttempts is how often the Space runtime is read after
+	// adding a custom domain before giving up on it being listed.
+	customDomainListAttempts = 5
+
+	// customDomainListInterval is the time between those reads.
+	customDomainListInterval = 2 * time.Second
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceDomainResource{}
+	_ resource.ResourceWithConfigure   = &SpaceDomainResource{}
+	_ resource.ResourceWithImportState = &SpaceDomainResource{}
+)
+
+// SpaceDomainResource defines the resource implementation.
+type SpaceDomainResource struct {
+	client *http.Client
+}
+
+// SpaceDomainResourceModel describes the resource data model.
+type SpaceDomainResourceModel struct {
+	ID         types.String     `tfsdk:"id"`
+	SpaceID    types.String     `tfsdk:"space_id"`
+	Domain     types.String     `tfsdk:"domain"`
+	Status     types.String     `tfsdk:"status"`
+	Verified   types.Bool       `tfsdk:"verified"`
+	DNSRecords []DNSRecordModel `tfsdk:"dns_records"`
+}
+
+// DNSRecordModel describes a DNS record required to verify a custom domain.
+type DNSRecordModel struct {
+	Type  types.String `tfsdk:"type"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+// customDomain is a custom domain of a Space as returned by the Hub.
+type customDomain struct {
+	Domain     string `json:"domain"`
+	Stage      string `json:"stage"`
+	DNSRecords []struct {
+		Type  string `json:"type"`
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"dnsRecords"`
+}
+
+func (r *SpaceDomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_domain"
+}
+
+func (r *SpaceDomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Serves a Space on a custom domain. Create the records in `dns_records` with your DNS provider to verify the domain.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "ID (`owner/name`) of the Space.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Custom domain, e.g. `demo.example.com`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Verification stage of the domain as reported by the Hub, e.g. `PENDING` or `READY`.",
+				Computed:            true,
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the domain has been verified and serves the Space.",
+				Computed:            true,
+			},
+			"dns_records": schema.ListNestedAttribute{
+				MarkdownDescription: "DNS records to create for the domain to be verified, as returned by the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *SpaceDomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SpaceDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceDomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/custom-domain", data.SpaceID.ValueString())
+	reqBody := fmt.Sprintf(`{"domain": "%s"}`, data.Domain.ValueString())
+	log.Printf("[DEBUG] Add Custom Domain Request Body: %s", reqBody)
+
+	httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add custom domain, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read add custom domain response, got error: %s", err))
+		return
+	}
+	log.Printf("[DEBUG] Add Custom Domain Response Body: %s", string(respBody))
+
+	if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to add custom domain, got status code: %d, response body: %s", httpResp.StatusCode, string(respBody)))
+		return
+	}
+
+	var added customDomain
+	err = json.Unmarshal(respBody, &added)
+	if err != nil {
+		resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode add custom domain response, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.SpaceID.ValueString(), data.Domain.ValueString()))
+	data.DNSRecords = []DNSRecordModel{}
+	setCustomDomain(data, added)
+
+	// The runtime may take a moment to list the new domain.
+	var domain customDomain
+	for attempt := 1; ; attempt++ {
+		domain, err = fetchCustomDomain(r.client, data.SpaceID.ValueString(), data.Domain.ValueString())
+		if !errors.Is(err, errSpaceNotFound) || attempt == customDomainListAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Stopped waiting for custom domain %s to be listed: %s", data.Domain.ValueString(), ctx.Err()))
+			return
+		case <-time.After(customDomainListInterval):
+		}
+	}
+	if errors.Is(err, errSpaceNotFound) {
+		// Keep the domain in state, tainted, so that it is removed again.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Custom domain %s was added but is not listed on space %s", data.Domain.ValueString(), data.SpaceID.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom domain status, got error: %s", err))
+		return
+	}
+
+	setCustomDomain(data, domain)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SpaceDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := fetchCustomDomain(r.client, data.SpaceID.ValueString(), data.Domain.ValueString())
+	if errors.Is(err, errSpaceNotFound) {
+		log.Printf("[DEBUG] Custom domain %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom domain, got error: %s", err))
+		return
+	}
+
+	if data.DNSRecords == nil {
+		data.DNSRecords = []DNSRecordModel{}
+	}
+	setCustomDomain(data, domain)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SpaceDomainResourceModel
+
+	// Every configurable attribute forces replacement, so there is nothing to
+	// send to the API and the current state stays as it is.
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SpaceDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SpaceDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/custom-domain", data.SpaceID.ValueString())
+	reqBody := fmt.Sprintf(`{"domain": "%s"}`, data.Domain.ValueString())
+
+	httpReq, err := http.NewRequest(http.MethodDelete, url, strings.NewReader(reqBody))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove custom domain, got error: %s", err))
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := r.client.Do(httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove custom domain, got error: %s", err))
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNotFound {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to remove custom domain, got status code: %d", httpResp.StatusCode))
+		return
+	}
+}
+
+func (r *SpaceDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The ID is "owner/name/domain"; the domain never contains a slash.
+	separator := strings.LastIndex(req.ID, "/")
+	if separator <= 0 || !strings.Contains(req.ID[:separator], "/") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form owner/name/domain, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), req.ID[:separator])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), req.ID[separator+1:])...)
+}
+
+// setCustomDomain sets the status attributes and, when the Hub returns them,
+// the DNS records from a custom domain. The records are only returned while
+// the domain is being verified, so earlier ones are kept otherwise.
+func setCustomDomain(data *SpaceDomainResourceModel, domain customDomain) {
+	data.Status = optionalString(domain.Stage)
+	data.Verified = types.BoolValue(domain.Stage == "READY")
+
+	if len(domain.DNSRecords) == 0 {
+		return
+	}
+
+	data.DNSRecords = make([]DNSRecordModel, 0, len(domain.DNSRecords))
+	for _, record := range domain.DNSRecords {
+		data.DNSRecords = append(data.DNSRecords, DNSRecordModel{
+			Type:  types.StringValue(record.Type),
+			Name:  types.StringValue(record.Name),
+			Value: types.StringValue(record.Value),
+		})
+	}
+}
+
+// fetchCustomDomain returns a custom domain from the domains listed in the
+// Space runtime. It returns errSpaceNotFound when either the Space or the
+// domain does not exist.
+func fetchCustomDomain(client *http.Client, spaceID, domain string) (customDomain, error) {
+	runtime, err := fetchSpaceRuntime(client, spaceID)
+	if err != nil {
+		return customDomain{}, err
+	}
+
+	encoded, err := json.Marshal(runtime["domains"])
+	if err != nil {
+		return customDomain{}, err
+	}
+
+	var domains []customDomain
+	if err := json.Unmarshal(encoded, &domains); err != nil {
+		return customDomain{}, fmt.Errorf("unable to decode space domains: %w", err)
+	}
+
+	for _, d := range domains {
+		if strings.EqualFold(d.Domain, domain) {
+			return d, nil
+		}
+	}
+
+	return customDomain{}, errSpaceNotFound
+}
+
+func NewSpaceDomainResource() resource.Resource {
+	return &SpaceDomainResource{}
+}
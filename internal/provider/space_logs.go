@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// spaceLogsURL returns the server-sent events stream of a Space's logs. kind
+// is either "build" or "run".
+func spaceLogsURL(spaceID, kind string) string {
+	return fmt.Sprintf("https://huggingface.co/api/spaces/%s/logs/%s", spaceID, kind)
+}
+
+// fetchSpaceLogs reads the log stream of a Space for at most duration and
+// returns its last lines. The stream never ends on its own while the Space
+// runs, so reaching the deadline is not an error.
+func fetchSpaceLogs(ctx context.Context, client *http.Client, spaceID, kind string, lines int, duration time.Duration) ([]string, error) {
+	streamCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(streamCtx, http.MethodGet, spaceLogsURL(spaceID, kind), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	tail := []string{}
+
+	scanner := bufio.NewScanner(httpResp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+
+		var event struct {
+			Data string `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(line)), &event); err != nil {
+			log.Printf("[DEBUG] Skipping undecodable %s log event: %s", kind, line)
+			continue
+		}
+
+		tail = append(tail, strings.TrimRight(event.Data, "\n"))
+		if len(tail) > lines {
+			tail = tail[len(tail)-lines:]
+		}
+	}
+
+	// Reaching the deadline ends the stream, but Terraform being interrupted
+	// is still an error.
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if streamCtx.Err() == nil {
+			return nil, err
+		}
+	}
+
+	return tail, nil
+}
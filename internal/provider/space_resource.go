@@ -3,28 +3,45 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &SpaceResource{}
-	_ resource.ResourceWithConfigure   = &SpaceResource{}
-	_ resource.ResourceWithImportState = &SpaceResource{}
+	_ resource.Resource                     = &SpaceResource{}
+	_ resource.ResourceWithConfigure        = &SpaceResource{}
+	_ resource.ResourceWithImportState      = &SpaceResource{}
+	_ resource.ResourceWithValidateConfig   = &SpaceResource{}
+	_ resource.ResourceWithConfigValidators = &SpaceResource{}
+	_ resource.ResourceWithModifyPlan       = &SpaceResource{}
 )
 
+// errSpaceNotFound is returned when a Space does not exist (anymore).
+var errSpaceNotFound = errors.New("space not found")
+
 // SpaceResource defines the resource implementation.
 type SpaceResource struct {
 	client *http.Client
@@ -34,6 +51,8 @@ type SpaceResource struct {
 type SpaceResourceModel struct {
 	ID        types.String `tfsdk:"id"`
 	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+	FullName  types.String `tfsdk:"full_name"`
 	Private   types.Bool   `tfsdk:"private"`
 	SDK       types.String `tfsdk:"sdk"`
 	Template  types.String `tfsdk:"template"`
@@ -42,6 +61,23 @@ type SpaceResourceModel struct {
 	Hardware  types.String `tfsdk:"hardware"`
 	Storage   types.String `tfsdk:"storage"`
 	SleepTime types.Int64  `tfsdk:"sleep_time"`
+
+	SDKVersion    types.String `tfsdk:"sdk_version"`
+	PythonVersion types.String `tfsdk:"python_version"`
+	AppPort       types.Int64  `tfsdk:"app_port"`
+
+	DuplicateFrom      types.String `tfsdk:"duplicate_from"`
+	DuplicateVariables types.Bool   `tfsdk:"duplicate_variables"`
+
+	AllowStorageDeletion types.Bool `tfsdk:"allow_storage_deletion"`
+
+	Paused          types.Bool `tfsdk:"paused"`
+	RestartTriggers types.Map  `tfsdk:"restart_triggers"`
+	FactoryReboot   types.Bool `tfsdk:"factory_reboot"`
+
+	DevMode types.Bool `tfsdk:"dev_mode"`
+
+	WaitForRunning types.Bool `tfsdk:"wait_for_running"`
 }
 
 func (r *SpaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,25 +88,57 @@ func (r *SpaceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed: true,
+				MarkdownDescription: "Canonical ID of the Space, `namespace/name`.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"name": schema.StringAttribute{
-				Required: true,
+				MarkdownDescription: "Short name of the Space, without the namespace. Changing it renames the Space.",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "User or organization that owns the Space. Defaults to the user of the token. Changing it moves the Space.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"full_name": schema.StringAttribute{
+				MarkdownDescription: "Full name of the Space, `namespace/name`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"private": schema.BoolAttribute{
 				Optional: true,
 				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"sdk": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "SDK of the Space: `gradio`, `streamlit`, `docker` or `static`. Changing it updates the card metadata and rebuilds the Space.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("gradio", "streamlit", "docker", "static"),
+				},
 			},
 			"template": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "ID (`owner/name`) of a template Space to create the Space from. Changing it forces a new Space to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"secrets": schema.MapAttribute{
 				Optional:    true,
@@ -81,89 +149,380 @@ func (r *SpaceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				ElementType: types.StringType,
 			},
 			"hardware": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "Hardware flavor of the Space, e.g. `cpu-basic`, `cpu-upgrade`, `t4-small` or `a10g-large`. Flavors added to the Hub after this release are checked against the live catalog. This is the requested hardware; while it is being changed, the Space may still run on its previous hardware, which `huggingface-spaces_space_runtime` reports.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"storage": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "Persistent storage tier: `small`, `medium` or `large`. When unset, storage attached outside Terraform is kept; removing a tier that was set here removes the storage.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(storageTiers...),
+				},
+			},
+			"allow_storage_deletion": schema.BoolAttribute{
+				MarkdownDescription: "Allow downgrading or removing `storage` in place. Persistent storage cannot be shrunk, so this deletes the existing storage and all data on it. When `false` (the default), such changes force a new Space to be created instead.",
+				Optional:            true,
 			},
 			"sleep_time": schema.Int64Attribute{
-				Optional: true,
-				Computed: true,
+				MarkdownDescription: "Seconds of inactivity before the Space goes to sleep, at least 300, or `-1` to never sleep. Not supported on `cpu-basic` hardware, the default when `hardware` is not set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Any(
+						int64validator.OneOf(-1),
+						int64validator.AtLeast(minSleepTime),
+					),
+				},
+			},
+			"sdk_version": schema.StringAttribute{
+				MarkdownDescription: "Version of the SDK to run, e.g. `4.36.1` for Gradio. Not supported for `docker` or `static` Spaces.",
+				Optional:            true,
+			},
+			"python_version": schema.StringAttribute{
+				MarkdownDescription: "Python version to build the Space with, e.g. `3.10`. Not supported for `docker` or `static` Spaces.",
+				Optional:            true,
+			},
+			"app_port": schema.Int64Attribute{
+				MarkdownDescription: "Port the application listens on. Only supported for `docker` Spaces.",
+				Optional:            true,
+			},
+			"duplicate_from": schema.StringAttribute{
+				MarkdownDescription: "ID (`owner/name`) of an existing Space to duplicate instead of creating an empty one. Conflicts with `template`. Changing it forces a new Space to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"duplicate_variables": schema.BoolAttribute{
+				MarkdownDescription: "Copy the variables of the `duplicate_from` Space. Variables set in `variables` take precedence.",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("duplicate_from")),
+				},
+			},
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Pause the Space. A paused Space does not run or bill until it is unpaused, which restarts it.",
+				Optional:            true,
+			},
+			"restart_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, restart the Space, e.g. a hash of rotated secrets.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"factory_reboot": schema.BoolAttribute{
+				MarkdownDescription: "Rebuild the Space from scratch, without cache, when `restart_triggers` change.",
+				Optional:            true,
+			},
+			"dev_mode": schema.BoolAttribute{
+				MarkdownDescription: "Enable dev mode, which allows connecting to the running container over SSH or VS Code. Not supported on `" + strings.Join(devModeUnsupportedHardware, "`, `") + "` hardware.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_running": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the Space to be running after it is created or updated. If it fails to build or start instead, the apply fails with the tail of the relevant log. A Space without an application file yet only produces a warning.",
+				Optional:            true,
 			},
 		},
 	}
 }
 
-func (r *SpaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
-		return
+func (r *SpaceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("template"),
+			path.MatchRoot("duplicate_from"),
+		),
 	}
+}
 
-	client, ok := req.ProviderData.(*http.Client)
+func (r *SpaceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SpaceResourceModel
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Spaces get the free hardware unless hardware is set.
+	freeHardware := data.Hardware.IsNull() || data.Hardware.ValueString() == freeHardwareFlavor
+	if !data.SleepTime.IsNull() && freeHardware {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sleep_time"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("sleep_time cannot be set on %s hardware, which always sleeps after 48 hours of inactivity", freeHardwareFlavor),
 		)
+	}
 
+	// The SDK may come from a variable that is not known yet.
+	if data.SDK.IsNull() || data.SDK.IsUnknown() {
 		return
 	}
 
-	r.client = client
+	sdk := data.SDK.ValueString()
+
+	if !data.AppPort.IsNull() && sdk != "docker" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("app_port"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("app_port is only supported for docker Spaces, got sdk: %s", sdk),
+		)
+	}
+
+	if sdk == "docker" || sdk == "static" {
+		if !data.SDKVersion.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sdk_version"),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("sdk_version is not supported for %s Spaces", sdk),
+			)
+		}
+
+		if !data.PythonVersion.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("python_version"),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("python_version is not supported for %s Spaces", sdk),
+			)
+		}
+	}
 }
 
-func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *SpaceResourceModel
+func (r *SpaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	var config SpaceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	url := "https://huggingface.co/api/repos/create"
+	var state *SpaceResourceModel
 
-	reqBody := fmt.Sprintf(`{"type": "space", "name": "%s", "private": %t, "sdk": "%s", "template": "%s", "hardware": "%s", "storage": "%s", "sleepTime": %d}`,
-		data.Name.ValueString(),
-		data.Private.ValueBool(),
-		data.SDK.ValueString(),
-		data.Template.ValueString(),
-		data.Hardware.ValueString(),
-		data.Storage.ValueString(),
-		data.SleepTime.ValueInt64(),
-	)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
-	httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state != nil {
+		storageConfigured, diags := privateFlag(ctx, req.Private, storageConfiguredKey)
+		resp.Diagnostics.Append(diags...)
+
+		planStorageChange(ctx, config, *state, storageConfigured, resp)
+
+		// Renaming or moving the Space changes its ID.
+		moved := !config.Namespace.IsNull() && !config.Namespace.IsUnknown() && !config.Namespace.Equal(state.Namespace)
+		if moved || config.Name.IsUnknown() || !config.Name.Equal(state.Name) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("full_name"), types.StringUnknown())...)
+		}
+	}
+
+	// The remaining checks need the API, which is not available before the
+	// provider is configured.
+	if r.client != nil {
+		// A template only matters on creation, as changing it forces
+		// replacement, so only look it up when it is set and changed.
+		templateSet := !config.Template.IsNull() && !config.Template.IsUnknown()
+		if templateSet && (state == nil || !state.Template.Equal(config.Template)) {
+			r.validateTemplateSDK(config, &resp.Diagnostics)
+		}
+
+		if state == nil || !state.Hardware.Equal(config.Hardware) {
+			r.validateHardwareFlavor(config, &resp.Diagnostics)
+		}
+	}
+
+	if config.DevMode.ValueBool() {
+		// Without hardware in the configuration the Space keeps its current
+		// hardware, or gets the free tier when it is created.
+		hardware := config.Hardware
+		if hardware.IsNull() {
+			hardware = types.StringValue(freeHardwareFlavor)
+			if state != nil {
+				hardware = state.Hardware
+			}
+		}
+
+		if !hardware.IsUnknown() && !supportsDevMode(hardware.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dev_mode"),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("dev_mode is not supported on %s hardware", hardware.ValueString()),
+			)
+		}
+	}
+}
+
+// storageConfiguredKey is the private state key recording whether storage is
+// set in the configuration. Removing storage from the configuration only
+// deletes it when it was set before; storage attached outside Terraform, as
+// on an imported Space, is left alone.
+const storageConfiguredKey = "storage_configured"
+
+// planStorageChange handles downgrades and removals of persistent storage,
+// which can only be applied by deleting the existing storage. Unless
+// allow_storage_deletion is set, they force the Space to be replaced.
+// Storage is only removed when it was configured before, as recorded by
+// storageConfigured.
+func planStorageChange(ctx context.Context, config, state SpaceResourceModel, storageConfigured bool, resp *resource.ModifyPlanResponse) {
+	if config.Storage.IsUnknown() || state.Storage.ValueString() == "" {
+		return
+	}
+
+	if config.Storage.IsNull() {
+		// Storage that was never configured keeps its state value.
+		if !storageConfigured {
+			return
+		}
+
+		// Storage is computed, so removing it from the configuration would
+		// otherwise keep the value from state.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("storage"), types.StringNull())...)
+	} else if storageTierIndex(config.Storage.ValueString()) >= storageTierIndex(state.Storage.ValueString()) {
+		return
+	}
+
+	if config.AllowStorageDeletion.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("storage"),
+			"Persistent Storage Will Be Deleted",
+			fmt.Sprintf("Changing storage from %s deletes the existing persistent storage of the space and all data on it.", state.Storage.ValueString()),
+		)
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("storage"))
+}
+
+// validateTemplateSDK checks that the configured sdk matches the SDK of the
+// configured template Space. A failed lookup is only a warning, so that
+// planning does not depend on the API being reachable.
+func (r *SpaceResource) validateTemplateSDK(config SpaceResourceModel, diags *diag.Diagnostics) {
+	if config.Template.IsNull() || config.Template.IsUnknown() || config.SDK.IsNull() || config.SDK.IsUnknown() {
+		return
+	}
+
+	templateSDK, err := fetchSpaceSDK(r.client, config.Template.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create space, got error: %s", err))
+		diags.AddAttributeWarning(
+			path.Root("template"),
+			"Unable to Check Template SDK",
+			fmt.Sprintf("Unable to read template space %s to check its sdk, got error: %s", config.Template.ValueString(), err),
+		)
 		return
 	}
-	defer httpResp.Body.Close()
 
-	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create space, got status code: %d", httpResp.StatusCode))
+	if templateSDK != "" && templateSDK != config.SDK.ValueString() {
+		diags.AddAttributeError(
+			path.Root("sdk"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Template %s uses sdk %s, but sdk is set to %s", config.Template.ValueString(), templateSDK, config.SDK.ValueString()),
+		)
+	}
+}
+
+// validateHardwareFlavor checks the configured hardware against the known
+// flavors, falling back to the live catalog for flavors added since release.
+func (r *SpaceResource) validateHardwareFlavor(config SpaceResourceModel, diags *diag.Diagnostics) {
+	if config.Hardware.IsNull() || config.Hardware.IsUnknown() || isKnownHardwareFlavor(config.Hardware.ValueString()) {
 		return
 	}
 
-	var responseData map[string]interface{}
-	err = json.NewDecoder(httpResp.Body).Decode(&responseData)
+	flavors, err := fetchHardwareFlavorNames(r.client)
 	if err != nil {
-		resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode create space response, got error: %s", err))
+		log.Printf("[DEBUG] Unable to fetch hardware catalog, got error: %s", err)
+		flavors = knownHardwareFlavors
+	}
+
+	for _, flavor := range flavors {
+		if flavor == config.Hardware.ValueString() {
+			return
+		}
+	}
+
+	diags.AddAttributeError(
+		path.Root("hardware"),
+		"Invalid Hardware Flavor",
+		fmt.Sprintf("Unknown hardware flavor %q, expected one of: %s", config.Hardware.ValueString(), strings.Join(flavors, ", ")),
+	)
+}
+
+func (r *SpaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
 		return
 	}
 
-	log.Printf("[DEBUG] Create Space Response: %+v", responseData)
+	client, ok := req.ProviderData.(*http.Client)
 
-	spaceName, ok := responseData["name"].(string)
 	if !ok {
-		resp.Diagnostics.AddError("Invalid Response", "Unable to extract space name from create space response")
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
 		return
 	}
 
+	r.client = client
+}
+
+func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SpaceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var spaceName string
+
+	if !data.DuplicateFrom.IsNull() {
+		repoID, err := r.duplicateSpace(data)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to duplicate space %s, got error: %s", data.DuplicateFrom.ValueString(), err))
+			return
+		}
+
+		spaceName = repoID
+	} else {
+		repoID, err := createRepo(r.client, "space", data.Name.ValueString(), data.Namespace, data.Private.ValueBool(), map[string]interface{}{
+			"sdk":       data.SDK.ValueString(),
+			"template":  data.Template.ValueString(),
+			"hardware":  data.Hardware.ValueString(),
+			"storage":   data.Storage.ValueString(),
+			"sleepTime": data.SleepTime.ValueInt64(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create space, got error: %s", err))
+			return
+		}
+
+		spaceName = repoID
+	}
+
 	data.ID = types.StringValue(spaceName)
 
 	// Add secrets
@@ -206,7 +565,71 @@ func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
+	// Apply SDK settings through the card metadata
+	if !data.SDKVersion.IsNull() || !data.PythonVersion.IsNull() || !data.AppPort.IsNull() {
+		err := updateRepoCardMetadata(r.client, "space", data.ID.ValueString(), spaceCardMetadata(data))
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space card metadata, got error: %s", err))
+			return
+		}
+	}
+
+	if data.DevMode.ValueBool() {
+		err := r.setDevMode(data.ID.ValueString(), true)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to enable dev mode, got error: %s", err))
+			return
+		}
+	}
+
+	if data.Paused.ValueBool() {
+		err := r.postSpaceAction(data.ID.ValueString(), "pause")
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to pause space, got error: %s", err))
+			return
+		}
+	}
+
+	// Fill in the computed attributes that were not configured. Configured
+	// values are kept, as the Hub may not report them yet while the new
+	// Space is starting.
+	planned := *data
+
+	err := r.readSpace(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read created space, got error: %s", err))
+		return
+	}
+
+	if !planned.Private.IsUnknown() {
+		data.Private = planned.Private
+	}
+	if !planned.SDK.IsUnknown() {
+		data.SDK = planned.SDK
+	}
+	if !planned.Hardware.IsUnknown() {
+		data.Hardware = planned.Hardware
+	}
+	if !planned.Storage.IsUnknown() {
+		data.Storage = planned.Storage
+	}
+	if !planned.SleepTime.IsUnknown() {
+		data.SleepTime = planned.SleepTime
+	}
+	data.SDKVersion = planned.SDKVersion
+	data.PythonVersion = planned.PythonVersion
+	data.AppPort = planned.AppPort
+	data.Variables = planned.Variables
+	data.DevMode = planned.DevMode
+	data.Paused = planned.Paused
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.setStorageConfigured(ctx, req.Config, resp.Private)...)
+
+	// The Space is saved first, so a failed start leaves it tainted.
+	if data.WaitForRunning.ValueBool() && !data.Paused.ValueBool() && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(waitForSpaceRunning(ctx, r.client, data.ID.ValueString(), nil)...)
+	}
 }
 
 func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -218,7 +641,16 @@ func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// ... (Retrieve space details using the GET /api/spaces/{space_id} endpoint)
+	err := r.readSpace(data)
+	if errors.Is(err, errSpaceNotFound) {
+		log.Printf("[DEBUG] Space %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space, got error: %s", err))
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -238,81 +670,61 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Check if the space needs to be renamed
-	if state.Name.ValueString() != data.Name.ValueString() {
-		url := "https://huggingface.co/api/repos/move"
-
-		fromRepo := state.ID.ValueString()
-		toRepo := fmt.Sprintf("%s/%s", strings.Split(state.ID.ValueString(), "/")[0], data.Name.ValueString())
-
-		reqBody := fmt.Sprintf(`{"fromRepo": "%s", "toRepo": "%s", "type": "space"}`, fromRepo, toRepo)
-		log.Printf("[DEBUG] Rename Space Request Body: %s", reqBody)
-
-		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
+	// Remember the current build, so that waiting for the Space to run does
+	// not mistake it for the outcome of this update.
+	var before *spaceStage
+	if data.WaitForRunning.ValueBool() {
+		stage, err := fetchSpaceStage(r.client, state.ID.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename space, got error: %s", err))
-			return
+			log.Printf("[DEBUG] Unable to read stage of space %s, got error: %s", state.ID.ValueString(), err)
 		}
-		defer httpResp.Body.Close()
+		before = stage
+	}
 
-		log.Printf("[DEBUG] Rename Space Response Status Code: %d", httpResp.StatusCode)
+	// Check if the space needs to be renamed or moved to another namespace
+	if state.Namespace.IsNull() {
+		stateNamespace, _ := splitRepoID(state.ID.ValueString())
+		state.Namespace = types.StringValue(stateNamespace)
+	}
 
-		respBody, err := ioutil.ReadAll(httpResp.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("API Response Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-			return
-		}
-		log.Printf("[DEBUG] Rename Space Response Body: %s", string(respBody))
+	namespace := state.Namespace
+	if !data.Namespace.IsUnknown() && !data.Namespace.IsNull() {
+		namespace = data.Namespace
+	}
 
-		if httpResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to rename space, got status code: %d", httpResp.StatusCode))
+	if state.Name.ValueString() != data.Name.ValueString() || !state.Namespace.Equal(namespace) {
+		fromRepo := state.ID.ValueString()
+		toRepo := fmt.Sprintf("%s/%s", namespace.ValueString(), data.Name.ValueString())
+
+		err := moveRepo(r.client, "space", fromRepo, toRepo)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to rename space, got error: %s", err))
 			return
 		}
 
 		state.ID = types.StringValue(toRepo)
 		state.Name = data.Name
+		state.Namespace = namespace
+		state.FullName = state.ID
 	}
 
 	// Check if the space visibility needs to be updated
-	if state.Private != data.Private {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/settings", data.ID.ValueString())
-
-		reqBody := fmt.Sprintf(`{"private": %t}`, data.Private.ValueBool())
-		log.Printf("[DEBUG] Update Space Visibility Request Body: %s", reqBody)
-
-		httpReq, err := http.NewRequest(http.MethodPut, url, strings.NewReader(reqBody))
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space visibility, got error: %s", err))
-			return
-		}
-		httpReq.Header.Set("Content-Type", "application/json")
-
-		httpResp, err := r.client.Do(httpReq)
+	if !data.Private.IsUnknown() && !state.Private.Equal(data.Private) {
+		err := updateRepoSettings(r.client, "space", state.ID.ValueString(), map[string]interface{}{
+			"private": data.Private.ValueBool(),
+		})
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space visibility, got error: %s", err))
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space visibility, got error: %s", err))
 			return
 		}
-		defer httpResp.Body.Close()
-
-		log.Printf("[DEBUG] Update Space Visibility Response Status Code: %d", httpResp.StatusCode)
 
-		respBody, err := ioutil.ReadAll(httpResp.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("API Response Error", fmt.Sprintf("Unable to read response body, got error: %s", err))
-			return
-		}
-		log.Printf("[DEBUG] Update Space Visibility Response Body: %s", string(respBody))
-
-		if httpResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space visibility, got status code: %d", httpResp.StatusCode))
-			return
-		}
+		state.Private = data.Private
 	}
 
 	// Update secrets
 	if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
 		// Delete existing secrets
-		secretsURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", data.ID.ValueString())
+		secretsURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", state.ID.ValueString())
 		secretsResp, err := r.client.Get(secretsURL)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve secrets, got error: %s", err))
@@ -329,7 +741,7 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 			}
 
 			for key := range existingSecrets {
-				deleteSecretURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", data.ID.ValueString())
+				deleteSecretURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", state.ID.ValueString())
 				deleteSecretReqBody := fmt.Sprintf(`{"key": "%s"}`, key)
 				deleteSecretReq, err := http.NewRequest(http.MethodDelete, deleteSecretURL, strings.NewReader(deleteSecretReqBody))
 				if err != nil {
@@ -356,7 +768,7 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		secretsMap := data.Secrets.Elements()
 		stateSecretsMap := make(map[string]attr.Value)
 		for key, value := range secretsMap {
-			secretURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", data.ID.ValueString())
+			secretURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/secrets", state.ID.ValueString())
 			secretReqBody := fmt.Sprintf(`{"key": "%s", "value": "%s"}`, key, value.(types.String).ValueString())
 			secretResp, err := r.client.Post(secretURL, "application/json", strings.NewReader(secretReqBody))
 			if err != nil {
@@ -377,7 +789,7 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Update variables
 	if !data.Variables.IsNull() && !data.Variables.IsUnknown() {
 		// Delete existing variables
-		variablesURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", data.ID.ValueString())
+		variablesURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", state.ID.ValueString())
 		variablesResp, err := r.client.Get(variablesURL)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to retrieve variables, got error: %s", err))
@@ -394,7 +806,7 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 			}
 
 			for key := range existingVariables {
-				deleteVariableURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", data.ID.ValueString())
+				deleteVariableURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", state.ID.ValueString())
 				deleteVariableReqBody := fmt.Sprintf(`{"key": "%s"}`, key)
 				deleteVariableReq, err := http.NewRequest(http.MethodDelete, deleteVariableURL, strings.NewReader(deleteVariableReqBody))
 				if err != nil {
@@ -421,7 +833,7 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		variablesMap := data.Variables.Elements()
 		stateVariablesMap := make(map[string]attr.Value)
 		for key, value := range variablesMap {
-			variableURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", data.ID.ValueString())
+			variableURL := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", state.ID.ValueString())
 			variableReqBody := fmt.Sprintf(`{"key": "%s", "value": "%s"}`, key, value.(types.String).ValueString())
 			variableResp, err := r.client.Post(variableURL, "application/json", strings.NewReader(variableReqBody))
 			if err != nil {
@@ -441,8 +853,8 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Check if the space hardware needs to be updated
-	if state.Hardware.ValueString() != data.Hardware.ValueString() {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/hardware", data.ID.ValueString())
+	if !data.Hardware.IsUnknown() && state.Hardware.ValueString() != data.Hardware.ValueString() {
+		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/hardware", state.ID.ValueString())
 		reqBody := fmt.Sprintf(`{"flavor": "%s"}`, data.Hardware.ValueString())
 		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
 		if err != nil {
@@ -468,35 +880,64 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Check if the space storage needs to be updated
-	if state.Storage.ValueString() != data.Storage.ValueString() {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/storage", data.ID.ValueString())
-		reqBody := fmt.Sprintf(`{"tier": "%s"}`, data.Storage.ValueString())
-		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space storage, got error: %s", err))
-			return
-		}
-		defer httpResp.Body.Close()
+	if !data.Storage.IsUnknown() && state.Storage.ValueString() != data.Storage.ValueString() {
+		// Persistent storage cannot be downgraded, only deleted along with its data
+		if state.Storage.ValueString() != "" && storageTierIndex(data.Storage.ValueString()) < storageTierIndex(state.Storage.ValueString()) {
+			url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/storage", state.ID.ValueString())
+			log.Printf("[DEBUG] Deleting %s storage of space %s", state.Storage.ValueString(), state.ID.ValueString())
 
-		if httpResp.StatusCode != http.StatusOK {
-			respBody, _ := ioutil.ReadAll(httpResp.Body)
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space storage, got status code: %d, response body: %s", httpResp.StatusCode, string(respBody)))
-			return
+			httpReq, err := http.NewRequest(http.MethodDelete, url, nil)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space storage, got error: %s", err))
+				return
+			}
+
+			httpResp, err := r.client.Do(httpReq)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space storage, got error: %s", err))
+				return
+			}
+			defer httpResp.Body.Close()
+
+			if httpResp.StatusCode != http.StatusOK {
+				respBody, _ := ioutil.ReadAll(httpResp.Body)
+				resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete space storage, got status code: %d, response body: %s", httpResp.StatusCode, string(respBody)))
+				return
+			}
+
+			state.Storage = types.StringNull()
 		}
 
-		var storageResp map[string]interface{}
-		err = json.NewDecoder(httpResp.Body).Decode(&storageResp)
-		if err != nil {
-			resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode update space storage response, got error: %s", err))
-			return
+		if !data.Storage.IsNull() {
+			url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/storage", state.ID.ValueString())
+			reqBody := fmt.Sprintf(`{"tier": "%s"}`, data.Storage.ValueString())
+			httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space storage, got error: %s", err))
+				return
+			}
+			defer httpResp.Body.Close()
+
+			if httpResp.StatusCode != http.StatusOK {
+				respBody, _ := ioutil.ReadAll(httpResp.Body)
+				resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space storage, got status code: %d, response body: %s", httpResp.StatusCode, string(respBody)))
+				return
+			}
+
+			var storageResp map[string]interface{}
+			err = json.NewDecoder(httpResp.Body).Decode(&storageResp)
+			if err != nil {
+				resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode update space storage response, got error: %s", err))
+				return
+			}
 		}
 
 		state.Storage = data.Storage
 	}
 
 	// Check if the space sleep time needs to be updated
-	if state.SleepTime.ValueInt64() != data.SleepTime.ValueInt64() {
-		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/sleeptime", data.ID.ValueString())
+	if !data.SleepTime.IsUnknown() && state.SleepTime.ValueInt64() != data.SleepTime.ValueInt64() {
+		url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/sleeptime", state.ID.ValueString())
 		reqBody := fmt.Sprintf(`{"seconds": %d}`, data.SleepTime.ValueInt64())
 		httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
 		if err != nil {
@@ -521,7 +962,98 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		state.SleepTime = data.SleepTime
 	}
 
+	// Check if the SDK settings in the card metadata need to be updated
+	sdkChanged := !data.SDK.IsUnknown() && !data.SDK.IsNull() && !state.SDK.Equal(data.SDK)
+	if sdkChanged || !state.SDKVersion.Equal(data.SDKVersion) || !state.PythonVersion.Equal(data.PythonVersion) || !state.AppPort.Equal(data.AppPort) {
+		values := spaceCardMetadata(data)
+		if sdkChanged {
+			values["sdk"] = cardString(data.SDK.ValueString())
+		}
+
+		err := updateRepoCardMetadata(r.client, "space", state.ID.ValueString(), values)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update space card metadata, got error: %s", err))
+			return
+		}
+
+		if sdkChanged {
+			state.SDK = data.SDK
+		}
+		state.SDKVersion = data.SDKVersion
+		state.PythonVersion = data.PythonVersion
+		state.AppPort = data.AppPort
+	}
+
+	// Check if dev mode needs to be toggled
+	if !data.DevMode.IsUnknown() && state.DevMode.ValueBool() != data.DevMode.ValueBool() {
+		err := r.setDevMode(state.ID.ValueString(), data.DevMode.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update dev mode, got error: %s", err))
+			return
+		}
+
+		state.DevMode = data.DevMode
+	}
+
+	// Check if the space needs to be paused, unpaused or restarted
+	if state.Paused.ValueBool() != data.Paused.ValueBool() {
+		action := "restart"
+		if data.Paused.ValueBool() {
+			action = "pause"
+		}
+
+		err := r.postSpaceAction(state.ID.ValueString(), action)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to %s space, got error: %s", action, err))
+			return
+		}
+	} else if !state.RestartTriggers.Equal(data.RestartTriggers) && !data.Paused.ValueBool() {
+		action := "restart"
+		if data.FactoryReboot.ValueBool() {
+			action = "restart?factory=true"
+		}
+
+		err := r.postSpaceAction(state.ID.ValueString(), action)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to restart space, got error: %s", err))
+			return
+		}
+	}
+
+	state.Paused = data.Paused
+	state.RestartTriggers = data.RestartTriggers
+	state.FactoryReboot = data.FactoryReboot
+	state.AllowStorageDeletion = data.AllowStorageDeletion
+	state.DuplicateVariables = data.DuplicateVariables
+
+	state.WaitForRunning = data.WaitForRunning
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(r.setStorageConfigured(ctx, req.Config, resp.Private)...)
+
+	if state.WaitForRunning.ValueBool() && !state.Paused.ValueBool() && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(waitForSpaceRunning(ctx, r.client, state.ID.ValueString(), before)...)
+	}
+}
+
+// setStorageConfigured records whether storage is set in the configuration,
+// for planStorageChange to tell a removal from storage that is not managed.
+func (r *SpaceResource) setStorageConfigured(ctx context.Context, config tfsdk.Config, private privateState) diag.Diagnostics {
+	var storage types.String
+	diags := config.GetAttribute(ctx, path.Root("storage"), &storage)
+	if diags.HasError() {
+		return diags
+	}
+
+	configured, flagDiags := privateFlag(ctx, private, storageConfiguredKey)
+	diags.Append(flagDiags...)
+	if diags.HasError() || configured == !storage.IsNull() {
+		return diags
+	}
+
+	diags.Append(setPrivateFlag(ctx, private, storageConfiguredKey, !storage.IsNull())...)
+
+	return diags
 }
 
 func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -533,30 +1065,415 @@ func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	url := "https://huggingface.co/api/repos/delete"
+	err := deleteRepo(r.client, "space", data.Name.ValueString(), data.Namespace)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete space, got error: %s", err))
+		return
+	}
+}
 
-	reqBody := fmt.Sprintf(`{"type": "space", "name": "%s"}`, data.Name.ValueString())
+// postSpaceAction calls a Space action endpoint such as "pause" or "restart".
+func (r *SpaceResource) postSpaceAction(spaceID, action string) error {
+	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/%s", spaceID, action)
+	log.Printf("[DEBUG] Space Action Request URL: %s", url)
 
-	httpReq, err := http.NewRequest(http.MethodDelete, url, strings.NewReader(reqBody))
+	httpResp, err := r.client.Post(url, "application/json", nil)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space, got error: %s", err))
-		return
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := r.client.Do(httpReq)
+	return nil
+}
+
+// setDevMode enables or disables dev mode on a Space.
+func (r *SpaceResource) setDevMode(spaceID string, enabled bool) error {
+	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/dev-mode", spaceID)
+	reqBody := fmt.Sprintf(`{"enabled": %t}`, enabled)
+	log.Printf("[DEBUG] Update Dev Mode Request Body: %s", reqBody)
+
+	httpResp, err := r.client.Post(url, "application/json", strings.NewReader(reqBody))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space, got error: %s", err))
-		return
+		return err
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete space, got status code: %d", httpResp.StatusCode))
-		return
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// fetchSpaceRuntime returns the runtime of a Space: its stage, hardware,
+// storage and other settings that only apply while it runs.
+func fetchSpaceRuntime(client *http.Client, spaceID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/runtime", spaceID)
+
+	httpResp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, errSpaceNotFound
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var runtime map[string]interface{}
+	err = json.NewDecoder(httpResp.Body).Decode(&runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtime, nil
+}
+
+// duplicateSpace duplicates the duplicate_from Space into the configured
+// namespace, or that of the authenticated user, and returns the ID of the new
+// Space.
+func (r *SpaceResource) duplicateSpace(data *SpaceResourceModel) (string, error) {
+	namespace := data.Namespace.ValueString()
+	if data.Namespace.IsNull() || data.Namespace.IsUnknown() {
+		name, err := fetchWhoamiName(r.client)
+		if err != nil {
+			return "", fmt.Errorf("unable to determine namespace: %w", err)
+		}
+		namespace = name
+	}
+
+	repoID := fmt.Sprintf("%s/%s", namespace, data.Name.ValueString())
+
+	payload := map[string]interface{}{
+		"repository": repoID,
+	}
+	if !data.Private.IsNull() && !data.Private.IsUnknown() {
+		payload["private"] = data.Private.ValueBool()
+	}
+	if !data.Hardware.IsNull() && !data.Hardware.IsUnknown() {
+		payload["hardware"] = data.Hardware.ValueString()
+	}
+	if !data.Storage.IsNull() && !data.Storage.IsUnknown() {
+		payload["storage"] = data.Storage.ValueString()
+	}
+	if !data.SleepTime.IsNull() && !data.SleepTime.IsUnknown() {
+		payload["sleepTimeSeconds"] = data.SleepTime.ValueInt64()
+	}
+
+	if data.DuplicateVariables.ValueBool() {
+		variables, err := fetchSpaceVariables(r.client, data.DuplicateFrom.ValueString())
+		if err != nil {
+			return "", fmt.Errorf("unable to read variables of %s: %w", data.DuplicateFrom.ValueString(), err)
+		}
+
+		var duplicated []map[string]string
+		for key, value := range variables {
+			duplicated = append(duplicated, map[string]string{"key": key, "value": value})
+		}
+		payload["variables"] = duplicated
+	}
+
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/duplicate", data.DuplicateFrom.ValueString())
+	log.Printf("[DEBUG] Duplicate Space Request Body: %s", reqBody)
+
+	httpResp, err := r.client.Post(url, "application/json", strings.NewReader(string(reqBody)))
+	if err != nil {
+		return "", err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(httpResp.Body)
+		return "", fmt.Errorf("got status code: %d, response body: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	return repoID, nil
+}
+
+// fetchSpaceVariables returns the variables of a Space keyed by name.
+func fetchSpaceVariables(client *http.Client, spaceID string) (map[string]string, error) {
+	url := fmt.Sprintf("https://huggingface.co/api/spaces/%s/variables", spaceID)
+
+	httpResp, err := client.Get(url)
+	if err != nil {
+		return nil, err
 	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var existingVariables map[string]struct {
+		Value string `json:"value"`
+	}
+	err = json.NewDecoder(httpResp.Body).Decode(&existingVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]string, len(existingVariables))
+	for key, variable := range existingVariables {
+		variables[key] = variable.Value
+	}
+
+	return variables, nil
+}
+
+// fetchSpace returns the details of a Space, including its card metadata and
+// runtime.
+func fetchSpace(client *http.Client, spaceID string) (map[string]interface{}, error) {
+	space, err := fetchRepo(client, "space", spaceID)
+	if errors.Is(err, errRepoNotFound) {
+		return nil, errSpaceNotFound
+	}
+
+	return space, err
+}
+
+// fetchSpaceSDK returns the SDK of an existing Space, or an empty string if
+// the Space does not declare one.
+func fetchSpaceSDK(client *http.Client, spaceID string) (string, error) {
+	space, err := fetchSpace(client, spaceID)
+	if err != nil {
+		return "", err
+	}
+
+	sdk, _ := space["sdk"].(string)
+
+	return sdk, nil
+}
+
+// spaceCardMetadata returns the card metadata values managed by the resource.
+// Attributes that are not set are removed from the card.
+func spaceCardMetadata(data *SpaceResourceModel) map[string]string {
+	values := map[string]string{
+		"sdk_version":    "",
+		"python_version": "",
+		"app_port":       "",
+	}
+
+	if !data.SDKVersion.IsNull() {
+		values["sdk_version"] = cardString(data.SDKVersion.ValueString())
+	}
+
+	if !data.PythonVersion.IsNull() {
+		values["python_version"] = cardString(data.PythonVersion.ValueString())
+	}
+
+	if !data.AppPort.IsNull() {
+		values["app_port"] = cardInt(data.AppPort.ValueInt64())
+	}
+
+	return values
+}
+
+// readSpace refreshes data from the Hub. Attributes the API does not expose,
+// such as secret values and the template, are left as they are.
+func (r *SpaceResource) readSpace(data *SpaceResourceModel) error {
+	space, err := fetchSpace(r.client, data.ID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Space JSON Response: %+v", space)
+
+	if id, ok := space["id"].(string); ok {
+		namespace, name := splitRepoID(id)
+		data.ID = types.StringValue(id)
+		data.FullName = types.StringValue(id)
+		data.Namespace = types.StringValue(namespace)
+		data.Name = types.StringValue(name)
+	}
+
+	if private, ok := space["private"].(bool); ok {
+		data.Private = types.BoolValue(private)
+	}
+
+	if sdk, ok := space["sdk"].(string); ok {
+		data.SDK = types.StringValue(sdk)
+	} else if data.SDK.IsUnknown() {
+		data.SDK = types.StringNull()
+	}
+
+	cardData, _ := space["cardData"].(map[string]interface{})
+
+	data.SDKVersion = cardDataString(cardData, "sdk_version", data.SDKVersion)
+	data.PythonVersion = cardDataString(cardData, "python_version", data.PythonVersion)
+	data.AppPort = cardDataInt64(cardData, "app_port")
+
+	runtime, _ := space["runtime"].(map[string]interface{})
+
+	data.Hardware = types.StringNull()
+	if hardware, ok := runtime["hardware"].(map[string]interface{}); ok {
+		if requested, ok := hardware["requested"].(string); ok {
+			data.Hardware = types.StringValue(requested)
+		} else if current, ok := hardware["current"].(string); ok {
+			data.Hardware = types.StringValue(current)
+		}
+	}
+
+	data.Storage = types.StringNull()
+	if storage, ok := runtime["storage"].(string); ok {
+		data.Storage = types.StringValue(storage)
+	}
+
+	data.SleepTime = types.Int64Null()
+	if sleepTime, ok := runtime["gcTimeout"].(float64); ok {
+		data.SleepTime = types.Int64Value(int64(sleepTime))
+	}
+
+	if devMode, ok := runtime["devMode"].(bool); ok {
+		data.DevMode = types.BoolValue(devMode)
+	} else if data.DevMode.IsNull() || data.DevMode.IsUnknown() {
+		data.DevMode = types.BoolValue(false)
+	}
+
+	// paused is not computed, so only report it when it is managed. A Space
+	// paused outside Terraform is otherwise left alone.
+	if !data.Paused.IsNull() {
+		stage, _ := runtime["stage"].(string)
+		data.Paused = types.BoolValue(stage == "PAUSED")
+	}
+
+	// Variables are only refreshed when they are managed by the resource.
+	if !data.Variables.IsNull() {
+		variables, err := fetchSpaceVariables(r.client, data.ID.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to read variables: %w", err)
+		}
+
+		values := make(map[string]attr.Value, len(variables))
+		for key, value := range variables {
+			values[key] = types.StringValue(value)
+		}
+		data.Variables, _ = types.MapValue(types.StringType, values)
+	}
+
+	if data.Template.IsUnknown() {
+		data.Template = types.StringNull()
+	}
+
+	return nil
+}
+
+// splitRepoID splits a canonical "namespace/name" repository ID.
+func splitRepoID(repoID string) (string, string) {
+	namespace, name, found := strings.Cut(repoID, "/")
+	if !found {
+		return "", repoID
+	}
+
+	return namespace, name
+}
+
+// jsonStringOrNull renders a string attribute as a JSON string, or null when
+// it is not set.
+func jsonStringOrNull(value types.String) string {
+	if value.IsNull() || value.IsUnknown() {
+		return "null"
+	}
+
+	encoded, _ := json.Marshal(value.ValueString())
+
+	return string(encoded)
 }
 
 func (r *SpaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	spaceID, err := r.parseSpaceImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected owner/name, https://huggingface.co/spaces/owner/name or https://owner-name.hf.space, got: %s (%s)", req.ID, err),
+		)
+		return
+	}
+
+	namespace, name := splitRepoID(spaceID)
+
+	// The remaining attributes are filled in by Read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), spaceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("full_name"), spaceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// parseSpaceImportID normalises the supported import ID formats to the
+// canonical owner/name ID of a Space.
+func (r *SpaceResource) parseSpaceImportID(importID string) (string, error) {
+	id := strings.TrimSpace(importID)
+	id = strings.TrimPrefix(id, "https://")
+	id = strings.TrimPrefix(id, "http://")
+	id = strings.TrimSuffix(id, "/")
+
+	host, rest, _ := strings.Cut(id, "/")
+
+	switch {
+	case host == "huggingface.co" || host == "www.huggingface.co":
+		if !strings.HasPrefix(rest, "spaces/") {
+			return "", fmt.Errorf("not a Space URL")
+		}
+
+		// Ignore anything after owner/name, such as /tree/main.
+		parts := strings.Split(strings.TrimPrefix(rest, "spaces/"), "/")
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return "", fmt.Errorf("missing owner or name")
+		}
+
+		return parts[0] + "/" + parts[1], nil
+	case strings.HasSuffix(host, ".hf.space"):
+		return r.resolveSpaceSubdomain(strings.TrimSuffix(host, ".hf.space"))
+	}
+
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("missing owner or name")
+	}
+
+	return id, nil
+}
+
+// resolveSpaceSubdomain finds the Space served at subdomain.hf.space. The
+// subdomain joins owner and name with a hyphen, and both may contain hyphens
+// themselves, so every split is tried against the Space's own subdomain.
+// The Hub also replaces "_" and "." with hyphens, which cannot be undone, so
+// such Spaces have to be imported by owner/name or website URL instead.
+func (r *SpaceResource) resolveSpaceSubdomain(subdomain string) (string, error) {
+	parts := strings.Split(subdomain, "-")
+
+	for i := 1; i < len(parts); i++ {
+		candidate := strings.Join(parts[:i], "-") + "/" + strings.Join(parts[i:], "-")
+
+		space, err := fetchSpace(r.client, candidate)
+		if errors.Is(err, errSpaceNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if spaceSubdomain, _ := space["subdomain"].(string); spaceSubdomain == "" || spaceSubdomain == subdomain {
+			if id, ok := space["id"].(string); ok {
+				return id, nil
+			}
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no Space found for subdomain %s; if the owner or name contains \"_\" or \".\", import it as owner/name instead", subdomain)
 }
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPlanStorageChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         types.String
+		config        types.String
+		configured    bool
+		allowDeletion bool
+		wantReplace   bool
+		wantWarning   bool
+		wantStorage   types.String
+	}{
+		{
+			name:        "add storage",
+			state:       types.StringNull(),
+			config:      types.StringValue("small"),
+			wantStorage: types.StringValue("small"),
+		},
+		{
+			name:        "upgrade",
+			state:       types.StringValue("small"),
+			config:      types.StringValue("large"),
+			wantStorage: types.StringValue("large"),
+		},
+		{
+			name:        "unchanged",
+			state:       types.StringValue("medium"),
+			config:      types.StringValue("medium"),
+			wantStorage: types.StringValue("medium"),
+		},
+		{
+			name:        "unknown",
+			state:       types.StringValue("large"),
+			config:      types.StringUnknown(),
+			wantStorage: types.StringUnknown(),
+		},
+		{
+			name:        "downgrade",
+			state:       types.StringValue("large"),
+			config:      types.StringValue("small"),
+			wantReplace: true,
+			wantStorage: types.StringValue("small"),
+		},
+		{
+			name:          "downgrade allowed",
+			state:         types.StringValue("large"),
+			config:        types.StringValue("small"),
+			allowDeletion: true,
+			wantWarning:   true,
+			wantStorage:   types.StringValue("small"),
+		},
+		{
+			name:        "remove",
+			state:       types.StringValue("small"),
+			config:      types.StringNull(),
+			configured:  true,
+			wantReplace: true,
+			wantStorage: types.StringNull(),
+		},
+		{
+			name:          "remove allowed",
+			state:         types.StringValue("small"),
+			config:        types.StringNull(),
+			configured:    true,
+			allowDeletion: true,
+			wantWarning:   true,
+			wantStorage:   types.StringNull(),
+		},
+		{
+			name:        "not configured",
+			state:       types.StringValue("small"),
+			config:      types.StringNull(),
+			wantStorage: types.StringValue("small"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			config := testSpaceModel()
+			config.Storage = tt.config
+			config.AllowStorageDeletion = types.BoolValue(tt.allowDeletion)
+
+			state := testSpaceModel()
+			state.Storage = tt.state
+
+			// Storage is computed, so an unset storage plans as its state
+			// value, or as unknown when there is none.
+			plan := config
+			if plan.Storage.IsNull() {
+				plan.Storage = state.Storage
+			}
+			if plan.Storage.IsNull() {
+				plan.Storage = types.StringUnknown()
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: testSpacePlan(t, plan)}
+
+			planStorageChange(ctx, config, state, tt.configured, resp)
+
+			if resp.Diagnostics.ErrorsCount() > 0 {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			gotReplace := len(resp.RequiresReplace) == 1 && resp.RequiresReplace[0].Equal(path.Root("storage"))
+			if gotReplace != tt.wantReplace {
+				t.Errorf("RequiresReplace = %v, want replace: %t", resp.RequiresReplace, tt.wantReplace)
+			}
+
+			if gotWarning := resp.Diagnostics.WarningsCount() > 0; gotWarning != tt.wantWarning {
+				t.Errorf("warning = %t, want %t", gotWarning, tt.wantWarning)
+			}
+
+			var storage types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("storage"), &storage)...)
+			if !storage.Equal(tt.wantStorage) {
+				t.Errorf("planned storage = %s, want %s", storage, tt.wantStorage)
+			}
+		})
+	}
+}
+
+// testSpaceModel returns a space model with all attributes null.
+func testSpaceModel() SpaceResourceModel {
+	return SpaceResourceModel{
+		Secrets:         types.MapNull(types.StringType),
+		Variables:       types.MapNull(types.StringType),
+		RestartTriggers: types.MapNull(types.StringType),
+	}
+}
+
+// testSpacePlan returns a plan of the space resource holding data.
+func testSpacePlan(t *testing.T, data SpaceResourceModel) tfsdk.Plan {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewSpaceResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+
+	return plan
+}
+
+func TestSpaceUpdateRenameWithSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	var requests []string
+	r := &SpaceResource{client: &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader("{}")),
+				Request:    req,
+			}, nil
+		}),
+	}}
+
+	state := testSpaceModel()
+	state.ID = types.StringValue("owner/old")
+	state.FullName = state.ID
+	state.Name = types.StringValue("old")
+	state.Namespace = types.StringValue("owner")
+	state.Secrets = types.MapValueMust(types.StringType, map[string]attr.Value{"TOKEN": types.StringValue("before")})
+
+	// Renaming plans the ID as unknown.
+	plan := state
+	plan.ID = types.StringUnknown()
+	plan.FullName = types.StringUnknown()
+	plan.Name = types.StringValue("new")
+	plan.Secrets = types.MapValueMust(types.StringType, map[string]attr.Value{"TOKEN": types.StringValue("after")})
+
+	priorState := testSpacePlan(t, state)
+	planned := testSpacePlan(t, plan)
+	req := resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+		Plan:   planned,
+		State:  tfsdk.State{Schema: priorState.Schema, Raw: priorState.Raw},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: priorState.Schema}}
+
+	r.Update(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	want := []string{
+		"POST /api/repos/move",
+		"GET /api/spaces/owner/new/secrets",
+		"POST /api/spaces/owner/new/secrets",
+	}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if id.ValueString() != "owner/new" {
+		t.Errorf("id = %s, want owner/new", id)
+	}
+}
+
+func TestParseSpaceImportID(t *testing.T) {
+	// Spaces served at owner-name.hf.space, keyed by their API path.
+	r := &SpaceResource{client: testHubClient(map[string]string{
+		"/api/spaces/owner/name":       `{"id": "owner/name", "subdomain": "owner-name"}`,
+		"/api/spaces/my-org/demo-app":  `{"id": "my-org/demo-app", "subdomain": "my-org-demo-app"}`,
+		"/api/spaces/my/org-demo-app":  `{"id": "my/org-demo-app", "subdomain": "my-org-demo-app-1"}`,
+		"/api/spaces/Owner/Name-Mixed": `{"id": "Owner/Name-Mixed"}`,
+	})}
+
+	tests := []struct {
+		importID string
+		want     string
+		wantErr  bool
+	}{
+		{importID: "owner/name", want: "owner/name"},
+		{importID: " owner/name ", want: "owner/name"},
+		{importID: "https://huggingface.co/spaces/owner/name", want: "owner/name"},
+		{importID: "https://www.huggingface.co/spaces/owner/name/", want: "owner/name"},
+		{importID: "http://huggingface.co/spaces/owner/name/tree/main", want: "owner/name"},
+		{importID: "https://owner-name.hf.space", want: "owner/name"},
+		{importID: "https://owner-name.hf.space/", want: "owner/name"},
+		{importID: "my-org-demo-app.hf.space", want: "my-org/demo-app"},
+		{importID: "https://Owner-Name-Mixed.hf.space", want: "Owner/Name-Mixed"},
+		{importID: "https://my_org-demo.hf.space", wantErr: true},
+		{importID: "https://unknown.hf.space", wantErr: true},
+		{importID: "https://huggingface.co/owner/name", wantErr: true},
+		{importID: "https://huggingface.co/spaces/owner", wantErr: true},
+		{importID: "owner", wantErr: true},
+		{importID: "owner/", wantErr: true},
+		{importID: "owner/name/extra", wantErr: true},
+		{importID: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importID, func(t *testing.T) {
+			got, err := r.parseSpaceImportID(tt.importID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSpaceImportID(%q) error = %v, want error: %t", tt.importID, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSpaceImportID(%q) = %q, want %q", tt.importID, got, tt.want)
+			}
+		})
+	}
+}
+
+// testHubClient returns a client that answers GET requests for the given
+// paths with the given JSON bodies, and everything else with a 404.
+func testHubClient(responses map[string]string) *http.Client {
+	return &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body, ok := responses[req.URL.Path]
+			status := http.StatusOK
+			if !ok || req.Method != http.MethodGet {
+				body, status = "{}", http.StatusNotFound
+			}
+
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// defaultLogLines is the number of log lines returned when log_lines is
+	// not set.
+	defaultLogLines = 50
+
+	// defaultLogDuration is how long, in seconds, each log stream is read when
+	// log_duration is not set.
+	defaultLogDuration = 5
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &SpaceRuntimeDataSource{}
+
+// SpaceRuntimeDataSource defines the data source implementation.
+type SpaceRuntimeDataSource struct {
+	client *http.Client
+}
+
+// SpaceRuntimeDataSourceModel describes the data source data model.
+type SpaceRuntimeDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	LogLines          types.Int64  `tfsdk:"log_lines"`
+	LogDuration       types.Int64  `tfsdk:"log_duration"`
+	Stage             types.String `tfsdk:"stage"`
+	Hardware          types.String `tfsdk:"hardware"`
+	RequestedHardware types.String `tfsdk:"requested_hardware"`
+	Replicas          types.Int64  `tfsdk:"replicas"`
+	RequestedReplicas types.Int64  `tfsdk:"requested_replicas"`
+	ErrorMessage      types.String `tfsdk:"error_message"`
+	BuildLogs         types.List   `tfsdk:"build_logs"`
+	ContainerLogs     types.List   `tfsdk:"container_logs"`
+}
+
+func (d *SpaceRuntimeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_runtime"
+}
+
+func (d *SpaceRuntimeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the runtime state of a Space along with the tail of its build and container logs.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Space, `namespace/name`.",
+				Required:            true,
+			},
+			"log_lines": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of log lines to return from each log. Defaults to %d; `0` skips reading logs.", defaultLogLines),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"log_duration": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Seconds to read each log stream for. Defaults to %d.", defaultLogDuration),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 60),
+				},
+			},
+			"stage": schema.StringAttribute{
+				MarkdownDescription: "Runtime stage, e.g. `BUILDING`, `RUNNING`, `BUILD_ERROR`, `RUNTIME_ERROR` or `PAUSED`.",
+				Computed:            true,
+			},
+			"hardware": schema.StringAttribute{
+				MarkdownDescription: "Hardware the Space currently runs on.",
+				Computed:            true,
+			},
+			"requested_hardware": schema.StringAttribute{
+				MarkdownDescription: "Hardware requested for the Space, which differs from `hardware` while it is being changed.",
+				Computed:            true,
+			},
+			"replicas": schema.Int64Attribute{
+				Computed: true,
+			},
+			"requested_replicas": schema.Int64Attribute{
+				Computed: true,
+			},
+			"error_message": schema.StringAttribute{
+				Computed: true,
+			},
+			"build_logs": schema.ListAttribute{
+				MarkdownDescription: "Last lines of the build log.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"container_logs": schema.ListAttribute{
+				MarkdownDescription: "Last lines of the container log.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *SpaceRuntimeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SpaceRuntimeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpaceRuntimeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runtime, err := fetchSpaceRuntime(d.client, data.ID.ValueString())
+	if errors.Is(err, errSpaceNotFound) {
+		resp.Diagnostics.AddError("Space Not Found", fmt.Sprintf("Space %s does not exist or is not accessible with the configured token", data.ID.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space runtime, got error: %s", err))
+		return
+	}
+
+	stage, _ := runtime["stage"].(string)
+	data.Stage = optionalString(stage)
+
+	errorMessage, _ := runtime["errorMessage"].(string)
+	data.ErrorMessage = optionalString(errorMessage)
+
+	hardware, _ := runtime["hardware"].(map[string]interface{})
+	current, _ := hardware["current"].(string)
+	requested, _ := hardware["requested"].(string)
+	data.Hardware = optionalString(current)
+	data.RequestedHardware = optionalString(requested)
+
+	replicas, _ := runtime["replicas"].(map[string]interface{})
+	data.Replicas = types.Int64Null()
+	if current, ok := replicas["current"].(float64); ok {
+		data.Replicas = types.Int64Value(int64(current))
+	}
+	data.RequestedReplicas = types.Int64Null()
+	if requested, ok := replicas["requested"].(float64); ok {
+		data.RequestedReplicas = types.Int64Value(int64(requested))
+	}
+
+	lines := int64(defaultLogLines)
+	if !data.LogLines.IsNull() {
+		lines = data.LogLines.ValueInt64()
+	}
+
+	duration := int64(defaultLogDuration)
+	if !data.LogDuration.IsNull() {
+		duration = data.LogDuration.ValueInt64()
+	}
+
+	data.BuildLogs = types.ListNull(types.StringType)
+	data.ContainerLogs = types.ListNull(types.StringType)
+
+	if lines > 0 {
+		for kind, target := range map[string]*types.List{"build": &data.BuildLogs, "run": &data.ContainerLogs} {
+			logs, err := fetchSpaceLogs(ctx, d.client, data.ID.ValueString(), kind, int(lines), time.Duration(duration)*time.Second)
+			if ctx.Err() != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s logs, got error: %s", kind, ctx.Err()))
+				return
+			}
+			// Logs are not available in every stage, e.g. container logs of a
+			// Space that failed to build, which should not hide the runtime.
+			if err != nil {
+				resp.Diagnostics.AddWarning("Client Error", fmt.Sprintf("Unable to read %s logs, got error: %s", kind, err))
+				continue
+			}
+
+			list, diags := types.ListValueFrom(ctx, types.StringType, logs)
+			resp.Diagnostics.Append(diags...)
+			*target = list
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func NewSpaceRuntimeDataSource() datasource.DataSource {
+	return &SpaceRuntimeDataSource{}
+}
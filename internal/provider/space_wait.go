@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	// spaceWaitInterval is how often the runtime is polled while waiting for
+	// a Space to start.
+	spaceWaitInterval = 10 * time.Second
+
+	// spaceWaitTimeout bounds how long to wait for a Space to start.
+	spaceWaitTimeout = 30 * time.Minute
+
+	// spaceRebuildGrace is how long an update may take to show up in the
+	// runtime. Until then, the stage from before the update is not trusted.
+	spaceRebuildGrace = 2 * time.Minute
+
+	// failureLogLines is the number of log lines included in the diagnostic
+	// of a Space that failed to start.
+	failureLogLines = 50
+
+	// failureLogDuration bounds how long the logs of a failed Space are read.
+	failureLogDuration = 10 * time.Second
+)
+
+// spaceFailureStages maps the runtime stages a Space cannot recover from on
+// its own to the log that explains them.
+var spaceFailureStages = map[string]string{
+	"BUILD_ERROR":   "build",
+	"CONFIG_ERROR":  "build",
+	"RUNTIME_ERROR": "run",
+}
+
+// spaceStage identifies a build of a Space by its runtime stage and the
+// commit it runs.
+type spaceStage struct {
+	Stage string
+	SHA   string
+}
+
+// runtimeStage returns the stage of a Space runtime.
+func runtimeStage(runtime map[string]interface{}) spaceStage {
+	stage, _ := runtime["stage"].(string)
+	sha, _ := runtime["sha"].(string)
+
+	return spaceStage{Stage: stage, SHA: sha}
+}
+
+// fetchSpaceStage returns the current stage of a Space.
+func fetchSpaceStage(client *http.Client, spaceID string) (*spaceStage, error) {
+	runtime, err := fetchSpaceRuntime(client, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := runtimeStage(runtime)
+
+	return &stage, nil
+}
+
+// waitForSpaceRunning polls the runtime of a Space until it is running. If
+// the Space fails instead, the returned error diagnostic includes the tail of
+// the log that explains the failure.
+//
+// before is the stage of the Space before it was updated, or nil for a new
+// Space. An update may take a moment to trigger a rebuild, so the stage from
+// before is only trusted once it has not changed for spaceRebuildGrace.
+func waitForSpaceRunning(ctx context.Context, client *http.Client, spaceID string, before *spaceStage) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	started := time.Now()
+	deadline := started.Add(spaceWaitTimeout)
+
+	for {
+		runtime, err := fetchSpaceRuntime(client, spaceID)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read space runtime, got error: %s", err))
+			return diags
+		}
+
+		current := runtimeStage(runtime)
+		stage := current.Stage
+		log.Printf("[DEBUG] Space %s is in stage %s at %s", spaceID, stage, current.SHA)
+
+		if before != nil && current == *before && time.Since(started) < spaceRebuildGrace {
+			log.Printf("[DEBUG] Space %s has not picked up the update yet", spaceID)
+		} else if spaceWaitDone(ctx, client, spaceID, runtime, &diags) {
+			return diags
+		}
+
+		if time.Now().After(deadline) {
+			diags.AddError("Timeout Waiting for Space", fmt.Sprintf("Space %s was still in stage %s after %s", spaceID, stage, spaceWaitTimeout))
+			return diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Interrupted Waiting for Space", fmt.Sprintf("Stopped waiting for space %s in stage %s: %s", spaceID, stage, ctx.Err()))
+			return diags
+		case <-time.After(spaceWaitInterval):
+		}
+	}
+}
+
+// spaceWaitDone reports whether the runtime of a Space ends the wait for it to
+// run, adding a diagnostic to diags if it does not run.
+func spaceWaitDone(ctx context.Context, client *http.Client, spaceID string, runtime map[string]interface{}, diags *diag.Diagnostics) bool {
+	stage, _ := runtime["stage"].(string)
+
+	switch stage {
+	case "RUNNING":
+		return true
+	case "NO_APP_FILE":
+		// A Space created without a template stays here until an
+		// application is pushed to it, which is not up to the provider.
+		diags.AddWarning("Space Has No Application", fmt.Sprintf("Space %s has no application file yet. It starts once one is pushed to its repository.", spaceID))
+		return true
+	}
+
+	if kind, failed := spaceFailureStages[stage]; failed {
+		errorMessage, _ := runtime["errorMessage"].(string)
+		diags.AddError("Space Failed to Start", spaceFailureDetail(ctx, client, spaceID, stage, errorMessage, kind))
+		return true
+	}
+
+	return false
+}
+
+// spaceFailureDetail describes why a Space failed, including the tail of its
+// build or container log when it can be read.
+func spaceFailureDetail(ctx context.Context, client *http.Client, spaceID, stage, errorMessage, kind string) string {
+	detail := fmt.Sprintf("Space %s ended in stage %s.", spaceID, stage)
+	if errorMessage != "" {
+		detail += fmt.Sprintf("\n\nError message: %s", errorMessage)
+	}
+
+	logs, err := fetchSpaceLogs(ctx, client, spaceID, kind, failureLogLines, failureLogDuration)
+	if err != nil {
+		return detail + fmt.Sprintf("\n\nUnable to read %s logs, got error: %s", kind, err)
+	}
+
+	if len(logs) > 0 {
+		detail += fmt.Sprintf("\n\nLast %d lines of the %s log:\n\n%s", len(logs), kind, strings.Join(logs, "\n"))
+	}
+
+	return detail
+}
@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultSpacesLimit bounds listings that are filtered by neither author nor
+// search.
+const defaultSpacesLimit = 1000
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &SpacesDataSource{}
+
+// SpacesDataSource defines the data source implementation.
+type SpacesDataSource struct {
+	client *http.Client
+}
+
+// SpacesDataSourceModel describes the data source data model.
+type SpacesDataSourceModel struct {
+	Author types.String        `tfsdk:"author"`
+	Search types.String        `tfsdk:"search"`
+	Filter types.List          `tfsdk:"filter"`
+	Sort   types.String        `tfsdk:"sort"`
+	Limit  types.Int64         `tfsdk:"limit"`
+	Spaces []SpaceSummaryModel `tfsdk:"spaces"`
+}
+
+// SpaceSummaryModel describes a Space in a listing.
+type SpaceSummaryModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Namespace    types.String `tfsdk:"namespace"`
+	Author       types.String `tfsdk:"author"`
+	SDK          types.String `tfsdk:"sdk"`
+	Likes        types.Int64  `tfsdk:"likes"`
+	Private      types.Bool   `tfsdk:"private"`
+	LastModified types.String `tfsdk:"last_modified"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Tags         types.List   `tfsdk:"tags"`
+}
+
+func (d *SpacesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_spaces"
+}
+
+func (d *SpacesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Spaces on the Hub, optionally filtered by author, search term and tags.",
+		Attributes: map[string]schema.Attribute{
+			"author": schema.StringAttribute{
+				MarkdownDescription: "Only list Spaces owned by this user or organization.",
+				Optional:            true,
+			},
+			"search": schema.StringAttribute{
+				MarkdownDescription: "Only list Spaces whose name contains this string.",
+				Optional:            true,
+			},
+			"filter": schema.ListAttribute{
+				MarkdownDescription: "Only list Spaces that have all of these tags.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"sort": schema.StringAttribute{
+				MarkdownDescription: "Property to sort by, in descending order, e.g. `likes` or `lastModified`.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of Spaces to return. When not set, all matching Spaces are returned if `author` or `search` is set, and the first " + strconv.Itoa(defaultSpacesLimit) + " otherwise.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"spaces": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the Space, `namespace/name`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"namespace": schema.StringAttribute{
+							Computed: true,
+						},
+						"author": schema.StringAttribute{
+							Computed: true,
+						},
+						"sdk": schema.StringAttribute{
+							Computed: true,
+						},
+						"likes": schema.Int64Attribute{
+							Computed: true,
+						},
+						"private": schema.BoolAttribute{
+							Computed: true,
+						},
+						"last_modified": schema.StringAttribute{
+							Computed: true,
+						},
+						"created_at": schema.StringAttribute{
+							Computed: true,
+						},
+						"tags": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SpacesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SpacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SpacesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{}
+	if !data.Author.IsNull() {
+		params.Set("author", data.Author.ValueString())
+	}
+	if !data.Search.IsNull() {
+		params.Set("search", data.Search.ValueString())
+	}
+	if !data.Sort.IsNull() {
+		params.Set("sort", data.Sort.ValueString())
+		params.Set("direction", "-1")
+	}
+	if !data.Filter.IsNull() {
+		var filters []string
+		resp.Diagnostics.Append(data.Filter.ElementsAs(ctx, &filters, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, filter := range filters {
+			params.Add("filter", filter)
+		}
+	}
+
+	limit := -1
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+		params.Set("limit", strconv.Itoa(limit))
+	} else if data.Author.IsNull() && data.Search.IsNull() {
+		// Without author or search, every Space on the Hub would match.
+		limit = defaultSpacesLimit
+	}
+
+	data.Spaces = []SpaceSummaryModel{}
+
+	pages := newPaginator(d.client, "https://huggingface.co/api/spaces?"+params.Encode(), limit)
+	for pages.HasNext() {
+		items, err := pages.Next(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list spaces, got error: %s", err))
+			return
+		}
+
+		for _, item := range items {
+			var space map[string]interface{}
+			err = json.Unmarshal(item, &space)
+			if err != nil {
+				resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode spaces response, got error: %s", err))
+				return
+			}
+
+			data.Spaces = append(data.Spaces, spaceSummary(space))
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// spaceSummary converts a Space from a listing. Listings omit many fields,
+// so missing ones are null.
+func spaceSummary(space map[string]interface{}) SpaceSummaryModel {
+	id, _ := space["id"].(string)
+	namespace, name := splitRepoID(id)
+	author, _ := space["author"].(string)
+	sdk, _ := space["sdk"].(string)
+	lastModified, _ := space["lastModified"].(string)
+	createdAt, _ := space["createdAt"].(string)
+
+	summary := SpaceSummaryModel{
+		ID:           types.StringValue(id),
+		Name:         types.StringValue(name),
+		Namespace:    optionalString(namespace),
+		Author:       optionalString(author),
+		SDK:          optionalString(sdk),
+		Likes:        types.Int64Null(),
+		Private:      types.BoolNull(),
+		LastModified: optionalString(lastModified),
+		CreatedAt:    optionalString(createdAt),
+		Tags:         optionalStringList(space["tags"]),
+	}
+
+	if likes, ok := space["likes"].(float64); ok {
+		summary.Likes = types.Int64Value(int64(likes))
+	}
+
+	if private, ok := space["private"].(bool); ok {
+		summary.Private = types.BoolValue(private)
+	}
+
+	return summary
+}
+
+func NewSpacesDataSource() datasource.DataSource {
+	return &SpacesDataSource{}
+}
@@ -0,0 +1,385 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// webhooksURL is the settings endpoint for the webhooks of the token's user.
+const webhooksURL = "https://huggingface.co/api/settings/webhooks"
+
+// errWebhookNotFound is returned when a webhook does not exist (anymore).
+var errWebhookNotFound = errors.New("webhook not found")
+
+// webhookWatchTypes are the kinds of entities a webhook can watch.
+var webhookWatchTypes = []string{
+	"user",
+	"org",
+	"model",
+	"dataset",
+	"space",
+}
+
+// webhookDomains are the kinds of events a webhook can be triggered by.
+var webhookDomains = []string{
+	"repo",
+	"discussion",
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &WebhookResource{}
+	_ resource.ResourceWithConfigure   = &WebhookResource{}
+	_ resource.ResourceWithImportState = &WebhookResource{}
+)
+
+// WebhookResource defines the resource implementation.
+type WebhookResource struct {
+	client *http.Client
+}
+
+// WebhookResourceModel describes the resource data model.
+type WebhookResourceModel struct {
+	ID       types.String          `tfsdk:"id"`
+	URL      types.String          `tfsdk:"url"`
+	Watched  []WebhookWatchedModel `tfsdk:"watched"`
+	Domains  types.List            `tfsdk:"domains"`
+	Secret   types.String          `tfsdk:"secret"`
+	Disabled types.Bool            `tfsdk:"disabled"`
+}
+
+// WebhookWatchedModel describes an entity watched by a webhook.
+type WebhookWatchedModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+// webhook is a webhook as returned by the webhooks API.
+type webhook struct {
+	ID      string `json:"id"`
+	URL     string `json:"url"`
+	Watched []struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"watched"`
+	Domains  []string `json:"domains"`
+	Disabled bool     `json:"disabled"`
+}
+
+func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Calls a URL when repositories, users or organizations on the Hub change, e.g. to trigger CI when a Space is pushed to.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL the events are sent to.",
+				Required:            true,
+			},
+			"watched": schema.ListNestedAttribute{
+				MarkdownDescription: "Repositories, users and organizations to watch.",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the watched entity: `" + strings.Join(webhookWatchTypes, "`, `") + "`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(webhookWatchTypes...),
+							},
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the user or organization, or ID (`owner/name`) of the repository.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"domains": schema.ListAttribute{
+				MarkdownDescription: "Events that trigger the webhook: `repo` for changes to repositories, `discussion` for discussions and pull requests. Defaults to both.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(webhookDomains...)),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "Secret sent in the `X-Webhook-Secret` header of each event, to authenticate the Hub.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Disable the webhook without deleting it.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *WebhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.saveWebhook(ctx, webhooksURL, data, false)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create webhook, got error: %s", err))
+		return
+	}
+
+	disable := data.Disabled.ValueBool()
+	r.setWebhook(data, created)
+
+	if disable {
+		err := r.setDisabled(data.ID.ValueString(), true)
+		if err != nil {
+			// The webhook is saved anyway, so that it is tainted rather than
+			// left behind on the Hub.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to disable webhook, got error: %s", err))
+			return
+		}
+		data.Disabled = types.BoolValue(true)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respBody, err := sendJSONRequest(r.client, http.MethodGet, webhooksURL+"/"+data.ID.ValueString(), nil, errWebhookNotFound)
+	if errors.Is(err, errWebhookNotFound) {
+		log.Printf("[DEBUG] Webhook %s no longer exists, removing it from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read webhook, got error: %s", err))
+		return
+	}
+
+	hook, err := decodeWebhook(respBody)
+	if err != nil {
+		resp.Diagnostics.AddError("JSON Decode Error", fmt.Sprintf("Unable to decode webhook, got error: %s", err))
+		return
+	}
+
+	r.setWebhook(data, hook)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state WebhookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clearSecret := !state.Secret.IsNull() && data.Secret.IsNull()
+
+	updated, err := r.saveWebhook(ctx, webhooksURL+"/"+state.ID.ValueString(), data, clearSecret)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update webhook, got error: %s", err))
+		return
+	}
+
+	if !state.Disabled.Equal(data.Disabled) {
+		err := r.setDisabled(state.ID.ValueString(), data.Disabled.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to enable or disable webhook, got error: %s", err))
+			return
+		}
+		updated.Disabled = data.Disabled.ValueBool()
+	}
+
+	r.setWebhook(data, updated)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *WebhookResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := sendJSONRequest(r.client, http.MethodDelete, webhooksURL+"/"+data.ID.ValueString(), nil, errWebhookNotFound)
+	if err != nil && !errors.Is(err, errWebhookNotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete webhook, got error: %s", err))
+		return
+	}
+}
+
+// saveWebhook creates a webhook, or updates it when url points to an existing
+// one, and returns the webhook as saved by the Hub. The secret is only sent
+// when it is set, or as null when clearSecret asks to remove it.
+func (r *WebhookResource) saveWebhook(ctx context.Context, url string, data *WebhookResourceModel, clearSecret bool) (*webhook, error) {
+	var watched []map[string]string
+	for _, w := range data.Watched {
+		watched = append(watched, map[string]string{
+			"type": w.Type.ValueString(),
+			"name": w.Name.ValueString(),
+		})
+	}
+
+	domains := webhookDomains
+	if !data.Domains.IsNull() && !data.Domains.IsUnknown() {
+		domains = nil
+		if diags := data.Domains.ElementsAs(ctx, &domains, false); diags.HasError() {
+			return nil, fmt.Errorf("unable to read domains")
+		}
+	}
+
+	body := map[string]interface{}{
+		"url":     data.URL.ValueString(),
+		"watched": watched,
+		"domains": domains,
+	}
+	if !data.Secret.IsNull() {
+		body["secret"] = data.Secret.ValueString()
+	} else if clearSecret {
+		body["secret"] = nil
+	}
+
+	respBody, err := sendJSONRequest(r.client, http.MethodPost, url, body, errWebhookNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeWebhook(respBody)
+}
+
+// setDisabled enables or disables a webhook.
+func (r *WebhookResource) setDisabled(webhookID string, disabled bool) error {
+	action := "enable"
+	if disabled {
+		action = "disable"
+	}
+
+	_, err := sendJSONRequest(r.client, http.MethodPost, fmt.Sprintf("%s/%s/%s", webhooksURL, webhookID, action), nil, errWebhookNotFound)
+
+	return err
+}
+
+// setWebhook copies the attributes reported by the Hub to data. The secret is
+// not read back.
+func (r *WebhookResource) setWebhook(data *WebhookResourceModel, hook *webhook) {
+	data.ID = types.StringValue(hook.ID)
+	data.URL = types.StringValue(hook.URL)
+	data.Disabled = types.BoolValue(hook.Disabled)
+
+	data.Watched = nil
+	for _, w := range hook.Watched {
+		data.Watched = append(data.Watched, WebhookWatchedModel{
+			Type: types.StringValue(w.Type),
+			Name: types.StringValue(w.Name),
+		})
+	}
+
+	domains := []attr.Value{}
+	for _, domain := range hook.Domains {
+		domains = append(domains, types.StringValue(domain))
+	}
+	data.Domains, _ = types.ListValue(types.StringType, domains)
+}
+
+// decodeWebhook decodes a webhook from a response of the webhooks API, which
+// wraps it in a "webhook" object.
+func decodeWebhook(respBody []byte) (*webhook, error) {
+	var wrapper struct {
+		Webhook webhook `json:"webhook"`
+	}
+
+	err := json.Unmarshal(respBody, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrapper.Webhook, nil
+}
+
+func (r *WebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func NewWebhookResource() resource.Resource {
+	return &WebhookResource{}
+}
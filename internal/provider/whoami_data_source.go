@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &WhoamiDataSource{}
+
+// WhoamiDataSource defines the data source implementation.
+type WhoamiDataSource struct {
+	client *http.Client
+}
+
+// WhoamiDataSourceModel describes the data source data model.
+type WhoamiDataSourceModel struct {
+	Username               types.String              `tfsdk:"username"`
+	FullName               types.String              `tfsdk:"full_name"`
+	Email                  types.String              `tfsdk:"email"`
+	Organizations          []WhoamiOrganizationModel `tfsdk:"organizations"`
+	TokenName              types.String              `tfsdk:"token_name"`
+	TokenRole              types.String              `tfsdk:"token_role"`
+	TokenGlobalPermissions types.List                `tfsdk:"token_global_permissions"`
+	TokenScopes            []WhoamiTokenScopeModel   `tfsdk:"token_scopes"`
+}
+
+// WhoamiOrganizationModel describes an organization the user belongs to.
+type WhoamiOrganizationModel struct {
+	Name     types.String `tfsdk:"name"`
+	FullName types.String `tfsdk:"full_name"`
+	Role     types.String `tfsdk:"role"`
+}
+
+// WhoamiTokenScopeModel describes the permissions a fine-grained token has on
+// a single user, organization or repository.
+type WhoamiTokenScopeModel struct {
+	EntityType  types.String `tfsdk:"entity_type"`
+	EntityName  types.String `tfsdk:"entity_name"`
+	Permissions types.List   `tfsdk:"permissions"`
+}
+
+// whoami is the response of the whoami-v2 endpoint.
+type whoami struct {
+	Name     string `json:"name"`
+	FullName string `json:"fullname"`
+	Email    string `json:"email"`
+	Orgs     []struct {
+		Name      string `json:"name"`
+		FullName  string `json:"fullname"`
+		RoleInOrg string `json:"roleInOrg"`
+	} `json:"orgs"`
+	Auth struct {
+		AccessToken struct {
+			DisplayName string `json:"displayName"`
+			Role        string `json:"role"`
+			FineGrained *struct {
+				Global []string `json:"global"`
+				Scoped []struct {
+					Entity struct {
+						Type string `json:"type"`
+						Name string `json:"name"`
+					} `json:"entity"`
+					Permissions []string `json:"permissions"`
+				} `json:"scoped"`
+			} `json:"fineGrained"`
+		} `json:"accessToken"`
+	} `json:"auth"`
+}
+
+func (d *WhoamiDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_whoami"
+}
+
+func (d *WhoamiDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Describes the user and token the provider is authenticated as.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Computed: true,
+			},
+			"full_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the user. Only returned for tokens allowed to read it.",
+				Computed:            true,
+			},
+			"organizations": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"full_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "Role of the user in the organization, e.g. `admin`, `write`, `contributor` or `read`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"token_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"token_role": schema.StringAttribute{
+				MarkdownDescription: "Role of the token: `read`, `write` or `fineGrained`.",
+				Computed:            true,
+			},
+			"token_global_permissions": schema.ListAttribute{
+				MarkdownDescription: "Permissions of a fine-grained token that are not tied to an entity, e.g. `inference.serverless.write`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"token_scopes": schema.ListNestedAttribute{
+				MarkdownDescription: "Permissions of a fine-grained token, per user, organization or repository.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.StringAttribute{
+							MarkdownDescription: "Type of the entity: `user`, `org`, `model`, `dataset` or `space`.",
+							Computed:            true,
+						},
+						"entity_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"permissions": schema.ListAttribute{
+							MarkdownDescription: "Permissions on the entity, e.g. `repo.content.read` or `repo.write`.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WhoamiDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WhoamiDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WhoamiDataSourceModel
+
+	user, err := fetchWhoami(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the authenticated user, got error: %s", err))
+		return
+	}
+
+	data.Username = types.StringValue(user.Name)
+	data.FullName = optionalString(user.FullName)
+	data.Email = optionalString(user.Email)
+
+	data.Organizations = []WhoamiOrganizationModel{}
+	for _, org := range user.Orgs {
+		data.Organizations = append(data.Organizations, WhoamiOrganizationModel{
+			Name:     types.StringValue(org.Name),
+			FullName: optionalString(org.FullName),
+			Role:     optionalString(org.RoleInOrg),
+		})
+	}
+
+	token := user.Auth.AccessToken
+	data.TokenName = optionalString(token.DisplayName)
+	data.TokenRole = optionalString(token.Role)
+	data.TokenGlobalPermissions = types.ListNull(types.StringType)
+	data.TokenScopes = []WhoamiTokenScopeModel{}
+
+	if token.FineGrained != nil {
+		var diags diag.Diagnostics
+
+		data.TokenGlobalPermissions, diags = types.ListValueFrom(ctx, types.StringType, token.FineGrained.Global)
+		resp.Diagnostics.Append(diags...)
+
+		for _, scope := range token.FineGrained.Scoped {
+			permissions, diags := types.ListValueFrom(ctx, types.StringType, scope.Permissions)
+			resp.Diagnostics.Append(diags...)
+
+			data.TokenScopes = append(data.TokenScopes, WhoamiTokenScopeModel{
+				EntityType:  types.StringValue(scope.Entity.Type),
+				EntityName:  types.StringValue(scope.Entity.Name),
+				Permissions: permissions,
+			})
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// fetchWhoami returns the user and token the client is authenticated as.
+func fetchWhoami(client *http.Client) (*whoami, error) {
+	httpResp, err := client.Get("https://huggingface.co/api/whoami-v2")
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status code: %d", httpResp.StatusCode)
+	}
+
+	var user whoami
+	err = json.NewDecoder(httpResp.Body).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Name == "" {
+		return nil, fmt.Errorf("the 'name' field is missing in the whoami response")
+	}
+
+	return &user, nil
+}
+
+// fetchWhoamiName returns the user name that owns the configured token.
+func fetchWhoamiName(client *http.Client) (string, error) {
+	user, err := fetchWhoami(client)
+	if err != nil {
+		return "", err
+	}
+
+	return user.Name, nil
+}
+
+func NewWhoamiDataSource() datasource.DataSource {
+	return &WhoamiDataSource{}
+}